@@ -0,0 +1,227 @@
+package ds
+
+import (
+	"context"
+
+	"github.com/influx6/sequence"
+)
+
+//directedSockets returns the sockets of the node followed in the direction
+func directedSockets(n Nodes, d Direction, incoming map[Nodes][]*Socket) []*Socket {
+	switch d {
+	case In:
+		return incoming[n]
+	case Both:
+		return append(OutSockets(n), incoming[n]...)
+	default:
+		return OutSockets(n)
+	}
+}
+
+//reverse returns the opposite of the direction, Both is its own opposite
+func (d Direction) reverse() Direction {
+	switch d {
+	case In:
+		return Out
+	case Both:
+		return Both
+	default:
+		return In
+	}
+}
+
+//searchFrontier holds one side of a bidirectional search
+type searchFrontier struct {
+	direction Direction
+	queue     []Nodes
+	keys      map[Nodes]*Socket
+	dist      map[Nodes]int
+	level     int
+}
+
+func newSearchFrontier(n Nodes, d Direction) *searchFrontier {
+	return &searchFrontier{
+		direction: d,
+		queue:     []Nodes{n},
+		keys:      make(map[Nodes]*Socket),
+		dist:      map[Nodes]int{n: 0},
+	}
+}
+
+//BidirectionalProc provides a breadth-first search between two nodes which grows a frontier from each end until they meet
+type BidirectionalProc struct {
+	fx       NodeDop
+	dir      *TransversalDirective
+	from, to Nodes
+	incoming map[Nodes][]*Socket
+	fwd, bwd *searchFrontier
+	path     []*Socket
+	done     bool
+}
+
+//BidirectionalSearch returns a BidirectionalProc searching from both ends, the end frontier walks against the directive Direction
+func BidirectionalSearch(fx NodeDop, dir *TransversalDirective) (*BidirectionalProc, error) {
+	if dir == nil {
+		dir = BFPreOrderDirective(nil, nil)
+	}
+
+	if dir.Heuristic == nil {
+		dir.Heuristic = defaultHeuristic
+	}
+
+	if fx == nil {
+		fx = func(Nodes, *Socket, int) error {
+			return nil
+		}
+	}
+
+	return &BidirectionalProc{
+		fx:  fx,
+		dir: dir,
+	}, nil
+}
+
+//Use sets the nodes to search between
+func (b *BidirectionalProc) Use(from, to Nodes) {
+	if from == nil || to == nil {
+		return
+	}
+
+	b.Reset()
+	b.from, b.to = from, to
+	b.fwd = newSearchFrontier(from, b.dir.Direction)
+	b.bwd = newSearchFrontier(to, b.dir.Direction.reverse())
+}
+
+//Reset clears the search
+func (b *BidirectionalProc) Reset() {
+	b.from, b.to = nil, nil
+	b.fwd, b.bwd = nil, nil
+	b.incoming = nil
+	b.path = nil
+	b.done = false
+}
+
+//Next expands a level of the smaller frontier, calling the NodeDop for every node it reaches
+func (b *BidirectionalProc) Next() error {
+	return b.next(nil)
+}
+
+//NextContext calls Next, stopping with the context error once the context is done
+func (b *BidirectionalProc) NextContext(ctx context.Context) error {
+	return b.next(ctx)
+}
+
+func (b *BidirectionalProc) next(ctx context.Context) error {
+	if b.from == nil || b.to == nil {
+		return ErrBadNode
+	}
+
+	if b.done {
+		return sequence.ErrBADINDEX
+	}
+
+	if b.from == b.to {
+		b.path = []*Socket{}
+		b.done = true
+		return nil
+	}
+
+	if b.incoming == nil {
+		b.incoming = incomingSockets(b.from.Graph())
+	}
+
+	side, other := b.fwd, b.bwd
+
+	if len(b.bwd.queue) < len(b.fwd.queue) {
+		side, other = b.bwd, b.fwd
+	}
+
+	if len(side.queue) == 0 {
+		return ErrNotFound
+	}
+
+	if b.dir.Depth > 0 && side.level+other.level+1 >= b.dir.Depth {
+		return ErrNotFound
+	}
+
+	level := side.queue
+	side.queue = nil
+	side.level++
+
+	var meet Nodes
+	best := -1
+
+	for _, n := range level {
+		if ctx != nil {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		for _, soc := range directedSockets(n, side.direction, b.incoming) {
+			co := peer(soc, n)
+
+			if _, ok := side.dist[co]; ok {
+				continue
+			}
+
+			if b.dir.Heuristic(co, soc) != nil {
+				continue
+			}
+
+			side.dist[co] = side.level
+			side.keys[co] = soc
+			side.queue = append(side.queue, co)
+
+			if err := b.fx(co, soc, side.level); err != nil {
+				return err
+			}
+
+			if d, ok := other.dist[co]; ok && (best < 0 || side.level+d < best) {
+				best = side.level + d
+				meet = co
+			}
+		}
+	}
+
+	if meet != nil {
+		b.path = b.join(meet)
+		b.done = true
+	}
+
+	return nil
+}
+
+//join returns the sockets from the start node through the meeting node to the end node
+func (b *BidirectionalProc) join(meet Nodes) []*Socket {
+	var path []*Socket
+
+	for cur := meet; cur != b.from; {
+		sc := b.fwd.keys[cur]
+		path = append(path, sc)
+		cur = peer(sc, cur)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	for cur := meet; cur != b.to; {
+		sc := b.bwd.keys[cur]
+		path = append(path, sc)
+		cur = peer(sc, cur)
+	}
+
+	return path
+}
+
+//Found returns true once the frontiers have met
+func (b *BidirectionalProc) Found() bool {
+	return b.done
+}
+
+//Path returns the sockets connecting the start node to the end node, nil until the frontiers have met
+func (b *BidirectionalProc) Path() []*Socket {
+	return b.path
+}
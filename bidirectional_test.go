@@ -0,0 +1,122 @@
+package ds
+
+import (
+	"testing"
+
+	"github.com/influx6/sequence"
+)
+
+func TestBidirectionalSearch(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5, 6, 7)
+	gs.Bind(1, 2, 0)
+	gs.Bind(2, 3, 0)
+	gs.Bind(3, 4, 0)
+	gs.Bind(4, 5, 0)
+	gs.Bind(1, 6, 0)
+	gs.Bind(6, 5, 0)
+	gs.Bind(5, 7, 0)
+
+	reached := 0
+
+	proc, err := BidirectionalSearch(func(Nodes, *Socket, int) error {
+		reached++
+		return nil
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proc.Use(gs.Get(1), gs.Get(7))
+
+	var serr error
+	for serr == nil {
+		serr = proc.Next()
+	}
+
+	if !proc.Found() || reached == 0 || serr != sequence.ErrBADINDEX {
+		t.Fatalf("Expected to find a path and end with sequence.ErrBADINDEX got %s", serr)
+	}
+
+	path := proc.Path()
+
+	if len(path) != 3 || path[0].From.Value() != 1 || path[1].From.Value() != 6 || path[2].To.Value() != 7 {
+		t.Fatalf("Unexpected path of %d sockets", len(path))
+	}
+
+	proc.Use(gs.Get(7), gs.Get(1))
+
+	for serr = nil; serr == nil; {
+		serr = proc.Next()
+	}
+
+	if serr != ErrNotFound || proc.Path() != nil {
+		t.Fatalf("Expected no outgoing path from 7 to 1 got %s", serr)
+	}
+
+	dir := BFPreOrderDirective(nil, nil)
+	dir.Direction = In
+
+	proc, _ = BidirectionalSearch(nil, dir)
+	proc.Use(gs.Get(7), gs.Get(1))
+
+	for serr = nil; serr == nil; {
+		serr = proc.Next()
+	}
+
+	if len(proc.Path()) != 3 || proc.Path()[0].To.Value() != 7 {
+		t.Fatal("Expected a path following incoming sockets from 7 to 1")
+	}
+
+	dir = BFPreOrderDirective(nil, nil)
+	dir.Depth = 3
+
+	proc, _ = BidirectionalSearch(nil, dir)
+	proc.Use(gs.Get(1), gs.Get(7))
+
+	for serr = nil; serr == nil; {
+		serr = proc.Next()
+	}
+
+	if serr != ErrNotFound {
+		t.Fatalf("Expected depth bound to stop the search got %s", serr)
+	}
+
+	dir = BFPreOrderDirective(nil, func(n Nodes, _ *Socket) error {
+		if n.Value() == 6 {
+			return ErrBadNode
+		}
+		return nil
+	})
+
+	proc, _ = BidirectionalSearch(nil, dir)
+	proc.Use(gs.Get(1), gs.Get(7))
+
+	for serr = nil; serr == nil; {
+		serr = proc.Next()
+	}
+
+	if len(proc.Path()) != 5 {
+		t.Fatalf("Expected the heuristic to force the path through 2, 3 and 4 got %d sockets", len(proc.Path()))
+	}
+}
+
+func TestBidirectionalZeroDepth(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3)
+	gs.Bind(1, 2, 0)
+	gs.Bind(2, 3, 0)
+
+	proc, _ := BidirectionalSearch(nil, &TransversalDirective{})
+	proc.Use(gs.Get(1), gs.Get(3))
+
+	var err error
+	for err == nil {
+		err = proc.Next()
+	}
+
+	if len(proc.Path()) != 2 {
+		t.Fatalf("Expected a zero depth to leave the search unbounded got %s", err)
+	}
+}
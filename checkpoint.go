@@ -0,0 +1,296 @@
+package ds
+
+import (
+	"fmt"
+	"sort"
+)
+
+//NodeKeyer provides a function type returning a stable id for a node
+type NodeKeyer func(Nodes) string
+
+//NodeResolver provides a function type returning the node of an id, nil if there is none
+type NodeResolver func(string) Nodes
+
+//CheckpointFrame is a node of a transversal frontier with the number of its sockets already walked
+type CheckpointFrame struct {
+	Node     string
+	Advances int
+}
+
+//CheckpointSocket names a socket by the ids of its ends
+type CheckpointSocket struct {
+	From, To string
+}
+
+//Checkpoint holds the state of a paused depth or breadth first transversal keyed by node ids, it is only valid while the graph is unchanged
+type Checkpoint struct {
+	Order     TransversalOrder
+	Direction Direction
+	Started   bool
+	WalkDepth int
+	Visits    int
+	Sources   []string
+	Sourced   int
+	Forested  int
+	Frontier  []CheckpointFrame
+	Visited   []string
+	Keys      map[string]CheckpointSocket
+	Roots     map[string]string
+	Pending   []string
+	Unlocked  bool
+	Depths    map[string]int
+}
+
+//countingIterator counts the advances of the socket iterator of a frontier node
+type countingIterator struct {
+	DeferIterator
+	advances int
+}
+
+//Next implements sequence.Iterable
+func (c *countingIterator) Next() error {
+	c.advances++
+	return c.DeferIterator.Next()
+}
+
+//ValueKeyer returns the printed value of the node as its id, values printing alike such as 1 and "1" share an id so IDKeyer should be preferred
+func ValueKeyer(n Nodes) string {
+	return fmt.Sprint(n.Value())
+}
+
+//ValueResolver returns a NodeResolver finding nodes of the graph by the ids given by ValueKeyer
+func ValueResolver(g Graphs) NodeResolver {
+	ids := make(map[string]Nodes)
+
+	if g != nil {
+		g.nodeSet().EachNode(func(n Nodes) {
+			ids[ValueKeyer(n)] = n
+		})
+	}
+
+	return func(id string) Nodes {
+		return ids[id]
+	}
+}
+
+//IDKeyer returns the stable id of the node as its checkpoint id
+func IDKeyer(n Nodes) string {
+	return n.ID()
+}
+
+//IDResolver returns a NodeResolver finding nodes of the graph by the ids given by IDKeyer
+func IDResolver(g Graphs) NodeResolver {
+	return func(id string) Nodes {
+		if g == nil {
+			return nil
+		}
+		return g.NodeByID(id)
+	}
+}
+
+//saveFrames returns the frames of a frontier cache
+func saveFrames(cache NodeCaches, key NodeKeyer) []CheckpointFrame {
+	frames := make([]CheckpointFrame, 0, len(cache))
+
+	for _, c := range cache {
+		frame := CheckpointFrame{Node: key(c.Node)}
+
+		if itr, ok := c.Itr.(*countingIterator); ok {
+			frame.Advances = itr.advances
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
+
+//loadFrames rebuilds a frontier cache from its frames, walking each socket iterator back to its position
+func (t *Transversor) loadFrames(frames []CheckpointFrame, resolve NodeResolver) (NodeCaches, error) {
+	cache := NewCache()
+
+	for _, frame := range frames {
+		n := resolve(frame.Node)
+		if n == nil {
+			return cache, ErrNotFound
+		}
+
+		c := t.nodeCache(n)
+
+		for i := 0; i < frame.Advances; i++ {
+			if err := c.Itr.Next(); err != nil {
+				return nil, ErrStaleCheckpoint
+			}
+		}
+
+		cache = append(cache, c)
+	}
+
+	return cache, nil
+}
+
+//socketBetween returns the socket leading from one node to the other
+func socketBetween(from, to Nodes) *Socket {
+	for _, sc := range OutSockets(from) {
+		if sc.To == to {
+			return sc
+		}
+	}
+	return nil
+}
+
+//Checkpoint returns the state of the transversal keyed by the ids of its nodes, BestFirst, Weighted and IterativeDeepening return ErrNoCheckpoint
+func (t *Transversor) Checkpoint(key NodeKeyer) (*Checkpoint, error) {
+	if t.save == nil {
+		return nil, ErrNoCheckpoint
+	}
+
+	cp := &Checkpoint{
+		Order:     t.directive.Order,
+		Direction: t.directive.Direction,
+		Started:   t.started > 0,
+		WalkDepth: t.WalkDepth(),
+		Visits:    t.Visits(),
+		Sourced:   t.sourced,
+		Forested:  t.forested,
+		Keys:      make(map[string]CheckpointSocket, len(t.keys)),
+		Roots:     make(map[string]string, len(t.roots)),
+	}
+
+	for _, n := range t.sources {
+		cp.Sources = append(cp.Sources, key(n))
+	}
+
+	for n := range t.visited {
+		cp.Visited = append(cp.Visited, key(n))
+	}
+
+	sort.Strings(cp.Visited)
+
+	for n, sc := range t.keys {
+		cp.Keys[key(n)] = CheckpointSocket{
+			From: key(sc.From),
+			To:   key(sc.To),
+		}
+	}
+
+	for n, r := range t.roots {
+		cp.Roots[key(n)] = key(r)
+	}
+
+	t.save(cp, key)
+
+	return cp, nil
+}
+
+//Resume resets the transversal to the state of the checkpoint, returning ErrNotFound for ids the resolver does not know and ErrStaleCheckpoint when a frontier node has lost sockets
+func (t *Transversor) Resume(cp *Checkpoint, resolve NodeResolver) error {
+	if t.load == nil {
+		return ErrNoCheckpoint
+	}
+
+	if cp.Order != t.directive.Order || cp.Direction != t.directive.Direction {
+		return fmt.Errorf("Checkpoint of %s %s order can not resume a %s %s transversal", cp.Order, cp.Direction, t.directive.Order, t.directive.Direction)
+	}
+
+	lookup := func(id string) (Nodes, error) {
+		n := resolve(id)
+		if n == nil {
+			return nil, ErrNotFound
+		}
+		return n, nil
+	}
+
+	t.Reset()
+
+	for _, id := range cp.Sources {
+		n, err := lookup(id)
+		if err != nil {
+			return err
+		}
+		t.sources = append(t.sources, n)
+	}
+
+	if len(t.sources) == 0 {
+		return ErrBadNode
+	}
+
+	t.from = t.sources[0]
+	t.sourced = cp.Sourced
+	t.forested = cp.Forested
+	t.walkdepth = int64(cp.WalkDepth)
+	t.visits = int64(cp.Visits)
+
+	if cp.Started {
+		t.started = 1
+	}
+
+	if t.directive.AllNodes && t.forested > 0 {
+		if gr := t.from.Graph(); gr != nil {
+			t.forest = gr.nodeSet().AllNodes()
+		}
+	}
+
+	for _, id := range cp.Visited {
+		n, err := lookup(id)
+		if err != nil {
+			return err
+		}
+		t.visited.Add(n)
+	}
+
+	for id, ks := range cp.Keys {
+		n, err := lookup(id)
+		if err != nil {
+			return err
+		}
+
+		from, err := lookup(ks.From)
+		if err != nil {
+			return err
+		}
+
+		to, err := lookup(ks.To)
+		if err != nil {
+			return err
+		}
+
+		sc := socketBetween(from, to)
+		if sc == nil {
+			return ErrNoEdge
+		}
+
+		t.keys[n] = sc
+	}
+
+	for id, rid := range cp.Roots {
+		n, err := lookup(id)
+		if err != nil {
+			return err
+		}
+
+		r, err := lookup(rid)
+		if err != nil {
+			return err
+		}
+
+		t.roots[n] = r
+	}
+
+	return t.load(cp, resolve)
+}
+
+//Checkpoint returns the state of the transversal keyed by the ids of its nodes
+func (p *GraphProc) Checkpoint(key NodeKeyer) (*Checkpoint, error) {
+	return p.trans.Checkpoint(key)
+}
+
+//Resume resets the transversal to the state of the checkpoint
+func (p *GraphProc) Resume(cp *Checkpoint, resolve NodeResolver) error {
+	if err := p.trans.Resume(cp, resolve); err != nil {
+		return err
+	}
+
+	p.g = p.trans.from.Graph()
+	return nil
+}
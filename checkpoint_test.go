@@ -0,0 +1,189 @@
+package ds
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func stepString(trans *Transversor) string {
+	from := "-"
+	if sc := trans.Key(); sc != nil {
+		from = fmt.Sprint(sc.From.Value())
+	}
+	return fmt.Sprintf("%v:%s:%d", trans.Node().Value(), from, trans.WalkDepth())
+}
+
+func TestCheckpointResume(t *testing.T) {
+	orders := []TransversalOrder{DFPreOrder, DFPostOrder, BFPreOrder, BFPostOrder}
+
+	for gi, gs := range orderGraphs() {
+		for _, order := range orders {
+			for _, direction := range []Direction{"", Both} {
+				dir := func() *TransversalDirective {
+					d := MakeTransversalDirective(-1, order, nil, nil)
+					d.Direction = direction
+					return d
+				}
+
+				full, _ := CreateGraphTransversor(dir())
+				full.Use(gs.Get(1))
+
+				var expect []string
+				for full.Next() == nil {
+					expect = append(expect, stepString(full))
+				}
+
+				for pause := 0; pause <= len(expect); pause++ {
+					first, _ := CreateGraphTransversor(dir())
+					first.Use(gs.Get(1))
+
+					var got []string
+					for i := 0; i < pause && first.Next() == nil; i++ {
+						got = append(got, stepString(first))
+					}
+
+					cp, err := first.Checkpoint(ValueKeyer)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					data, err := json.Marshal(cp)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					var stored Checkpoint
+					if err := json.Unmarshal(data, &stored); err != nil {
+						t.Fatal(err)
+					}
+
+					second, _ := CreateGraphTransversor(dir())
+					if err := second.Resume(&stored, ValueResolver(gs)); err != nil {
+						t.Fatal(err)
+					}
+
+					for second.Next() == nil {
+						got = append(got, stepString(second))
+					}
+
+					if fmt.Sprint(got) != fmt.Sprint(expect) {
+						t.Fatalf("graph %d %s %q paused at %d: expected %v got %v", gi, order, direction, pause, expect, got)
+					}
+
+					if second.Visits() != len(expect) {
+						t.Fatalf("Expected visits to carry over, got %d", second.Visits())
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCheckpointErrors(t *testing.T) {
+	gs := orderGraphs()[0]
+
+	weighted, _ := CreateGraphTransversor(WeightedDirective(nil, nil))
+	weighted.Use(gs.Get(1))
+
+	if _, err := weighted.Checkpoint(ValueKeyer); err != ErrNoCheckpoint {
+		t.Fatalf("Expected ErrNoCheckpoint got %v", err)
+	}
+
+	dfs, _ := DepthFirstPreOrderIterator(nil, nil)
+	dfs.Use(gs.Get(1))
+	dfs.Next()
+	dfs.Next()
+
+	cp, _ := dfs.Checkpoint(ValueKeyer)
+
+	bfs, _ := BreadthFirstPreOrderIterator(nil, nil)
+	if err := bfs.Resume(cp, ValueResolver(gs)); err == nil {
+		t.Fatal("Expected a checkpoint of another order to be refused")
+	}
+
+	other, _ := DepthFirstPreOrderIterator(nil, nil)
+	if err := other.Resume(cp, ValueResolver(NewGraph())); err != ErrNotFound {
+		t.Fatalf("Expected unknown ids to fail with ErrNotFound got %v", err)
+	}
+
+	cycle := NewGraph()
+	cycle.Add(1, 2, 3)
+	cycle.Bind(1, 2, 0)
+	cycle.Bind(2, 1, 0)
+	cycle.Bind(2, 3, 0)
+
+	paused, _ := DepthFirstPreOrderIterator(nil, nil)
+	paused.Use(cycle.Get(1))
+
+	for i := 0; i < 3; i++ {
+		paused.Next()
+	}
+
+	cp, _ = paused.Checkpoint(IDKeyer)
+	dropSockets(cycle.Get(2), cycle.Get(1))
+
+	resumed, _ := DepthFirstPreOrderIterator(nil, nil)
+	if err := resumed.Resume(cp, IDResolver(cycle)); err != ErrStaleCheckpoint {
+		t.Fatalf("Expected a frontier node that lost a socket to fail with ErrStaleCheckpoint got %v", err)
+	}
+
+	for _, order := range []TransversalOrder{BestFirst, Weighted, IterativeDeepening} {
+		trans, err := CreateGraphTransversor(MakeTransversalDirective(-1, order, nil, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := trans.Checkpoint(IDKeyer); err != ErrNoCheckpoint {
+			t.Fatalf("%s: expected ErrNoCheckpoint got %v", order, err)
+		}
+	}
+}
+
+func TestCheckpointIDs(t *testing.T) {
+	gs := orderGraphs()[1]
+
+	full, _ := BreadthFirstPreOrderIterator(nil, nil)
+	full.Use(gs.Get(1))
+
+	var expect []string
+	for full.Next() == nil {
+		expect = append(expect, stepString(full))
+	}
+
+	first, _ := BreadthFirstPreOrderIterator(nil, nil)
+	first.Use(gs.Get(1))
+
+	var got []string
+	for i := 0; i < 3 && first.Next() == nil; i++ {
+		got = append(got, stepString(first))
+	}
+
+	cp, err := first.Checkpoint(IDKeyer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cp.Sources[0] != gs.Get(1).ID() {
+		t.Fatalf("Expected the source id %s got %s", gs.Get(1).ID(), cp.Sources[0])
+	}
+
+	clone := gs.Clone()
+
+	second, _ := BreadthFirstPreOrderIterator(nil, nil)
+	if err := second.Resume(cp, IDResolver(clone)); err != nil {
+		t.Fatal(err)
+	}
+
+	for second.Next() == nil {
+		got = append(got, stepString(second))
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(expect) {
+		t.Fatalf("Expected %v got %v", expect, got)
+	}
+
+	if second.Node().Graph() != Graphs(clone) {
+		t.Fatal("Expected the resumed transversal to walk the clone")
+	}
+}
@@ -0,0 +1,134 @@
+package ds
+
+import (
+	"sync/atomic"
+
+	"github.com/influx6/sequence"
+)
+
+//IterativeDeepeningOrder returns an iterative deepening search provider
+func IterativeDeepeningOrder(directive *TransversalDirective) (t *Transversor) {
+	t = MakeTransversor(directive)
+
+	var cache = NewCache()
+	var levels []int
+	var roots []Nodes
+	var pass, limit int
+	var fresh bool
+
+	dist := make(map[Nodes]int)
+
+	t.reset = func() {
+		cache.Reset()
+		levels = nil
+		roots = nil
+		pass, limit = 0, 0
+		fresh = false
+		dist = make(map[Nodes]int)
+	}
+
+	t.next = func() error {
+		for {
+			if err := t.interrupted(); err != nil {
+				return err
+			}
+
+			if cache.Length() <= 0 {
+				if pass < len(roots) {
+					root := roots[pass]
+					pass++
+
+					dist[root] = 0
+					atomic.StoreInt64(&t.walkdepth, 0)
+
+					if limit > 0 {
+						cache.addNodeCache(t.nodeCache(root))
+						levels = append(levels, 0)
+					}
+
+					if !t.directive.Revisits(root, t.visited.Valid(root)) {
+						continue
+					}
+
+					fresh = fresh || !t.visited.Valid(root)
+					t.visited.Add(root)
+					t.current = root
+					return nil
+				}
+
+				if fresh && (t.directive.Depth == -1 || limit+1 < t.directive.Depth) {
+					limit++
+				} else {
+					roots = t.nextRoots()
+
+					if len(roots) == 0 {
+						t.current = nil
+						return sequence.ErrBADINDEX
+					}
+
+					atomic.StoreInt64(&t.started, 1)
+					limit = 0
+				}
+
+				pass = 0
+				fresh = false
+				dist = make(map[Nodes]int)
+				continue
+			}
+
+			cur, err := cache.LastCache()
+
+			if err != nil {
+				t.current = nil
+				return err
+			}
+
+			level := levels[len(levels)-1]
+
+			if err := cur.Itr.Next(); err != nil {
+				cache.Uncache()
+				levels = levels[:len(levels)-1]
+				continue
+			}
+
+			soc, ok := cur.Itr.Value().(*Socket)
+
+			if !ok {
+				t.current = nil
+				return ErrBadEdgeType
+			}
+
+			co := peer(soc, cur.Node)
+			depth := level + 1
+
+			if d, ok := dist[co]; ok && d <= depth {
+				continue
+			}
+
+			atomic.StoreInt64(&t.walkdepth, int64(depth))
+
+			if t.directive.Heuristic(co, soc) != nil {
+				continue
+			}
+
+			dist[co] = depth
+
+			if depth < limit {
+				cache.addNodeCache(t.nodeCache(co))
+				levels = append(levels, depth)
+			}
+
+			if !t.directive.Revisits(co, t.visited.Valid(co)) {
+				continue
+			}
+
+			fresh = fresh || !t.visited.Valid(co)
+			t.link(co, cur.Node, soc)
+			t.visited.Add(co)
+			t.current = co
+			return nil
+		}
+	}
+
+	return
+}
@@ -0,0 +1,107 @@
+package ds
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIterativeDeepening(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+	gs.Bind(1, 2, 0)
+	gs.Bind(2, 3, 0)
+	gs.Bind(3, 4, 0)
+	gs.Bind(1, 4, 0)
+	gs.Bind(1, 5, 0)
+	gs.Bind(4, 1, 0)
+
+	var order []string
+
+	proc, err := Search(func(n Nodes, _ *Socket, depth int) error {
+		order = append(order, fmt.Sprintf("%v:%d", n.Value(), depth))
+		return nil
+	}, IDDFSDirective(-1, nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proc.Use(gs.Get(1))
+
+	for proc.Next() == nil {
+	}
+
+	if fmt.Sprint(order) != "[1:0 2:1 4:1 5:1 3:2]" {
+		t.Fatalf("Unexpected iterative deepening order %v", order)
+	}
+
+	if proc.Parent(gs.Get(4)).Value() != 1 {
+		t.Fatal("Expected 4 to be visited at its shallowest depth")
+	}
+
+	order = nil
+	proc, _ = Search(func(n Nodes, _ *Socket, depth int) error {
+		order = append(order, fmt.Sprintf("%v:%d", n.Value(), depth))
+		return nil
+	}, IDDFSDirective(2, nil, nil))
+
+	proc.Use(gs.Get(1))
+
+	for proc.Next() == nil {
+	}
+
+	if fmt.Sprint(order) != "[1:0 2:1 4:1 5:1]" {
+		t.Fatalf("Unexpected depth bounded order %v", order)
+	}
+}
+
+func TestIterativeDeepeningChain(t *testing.T) {
+	_, root := chainGraph(200)
+
+	trans, err := CreateGraphTransversor(IDDFSDirective(-1, nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trans.Use(root)
+
+	count := 0
+	for trans.Next() == nil {
+		if trans.WalkDepth() != count {
+			t.Fatalf("Expected node %d at depth %d got %d", count, count, trans.WalkDepth())
+		}
+		count++
+	}
+
+	if count != 200 {
+		t.Fatalf("Expected 200 nodes got %d", count)
+	}
+}
+
+func TestIterativeDeepeningMaxDepth(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+	gs.Bind(1, 2, 0)
+	gs.Bind(2, 3, 0)
+	gs.Bind(3, 4, 0)
+
+	builder, err := Filter(IDDFSDirective(-1, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := builder.Evaluator(MaxDepth(1)).Transverse(gs.Get(1))
+
+	for filter.Next() == nil {
+	}
+
+	var values []interface{}
+	for _, n := range filter.Nodes() {
+		values = append(values, n.Value())
+	}
+
+	if fmt.Sprint(values) != "[1 2]" {
+		t.Fatalf("Expected nodes within depth 1 [1 2] got %v", values)
+	}
+}
@@ -19,6 +19,14 @@ var (
 	ErrBadBind = errors.New("BadBind unable to bind nodes")
 	//ErrBadEdgeType indicates that the value of a iterator is not a *Socket
 	ErrBadEdgeType = errors.New("value is not a *Socket type")
+	//ErrCyclicGraph indicates the graph contains a cycle where an acyclic graph was expected
+	ErrCyclicGraph = errors.New("Graph contains a cycle")
+	//ErrBadGraph indicates no graph was supplied where one was needed
+	ErrBadGraph = errors.New("Invalid Graph")
+	//ErrDuplicateID indicates the graph already holds a node with the id
+	ErrDuplicateID = errors.New("Node id already in graph")
+	//ErrDuplicateValue indicates the graph already holds a node with the value
+	ErrDuplicateValue = errors.New("Node value already in graph")
 )
 
 type (
@@ -1,5 +1,28 @@
 package ds
 
+import (
+	"reflect"
+	"regexp"
+)
+
+//Comparison provides an operator for typed value comparisons
+type Comparison string
+
+const (
+	//Equals matches values that are equal
+	Equals Comparison = "=="
+	//NotEquals matches values that are not equal
+	NotEquals Comparison = "!="
+	//LessThan matches values below the giving value
+	LessThan Comparison = "<"
+	//LessOrEqual matches values at or below the giving value
+	LessOrEqual Comparison = "<="
+	//GreaterThan matches values above the giving value
+	GreaterThan Comparison = ">"
+	//GreaterOrEqual matches values at or above the giving value
+	GreaterOrEqual Comparison = ">="
+)
+
 //EdgeAttr provides a evaluator for checking a section attribute existence
 func EdgeAttr(attr string) NodeEval {
 	return func(n Nodes, soc *Socket, depth int) bool {
@@ -89,3 +112,240 @@ func DepthRange(min, max int) NodeEval {
 		return false
 	}
 }
+
+//And provides a evaluator that passes when all the evaluators pass
+func And(evals ...NodeEval) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		for _, ev := range evals {
+			if !ev(n, soc, depth) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+//Or provides a evaluator that passes when any of the evaluators pass
+func Or(evals ...NodeEval) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		for _, ev := range evals {
+			if ev(n, soc, depth) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//Not provides a evaluator that inverts the evaluator
+func Not(eval NodeEval) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		return !eval(n, soc, depth)
+	}
+}
+
+//Any provides a evaluator that always passes
+func Any() NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		return true
+	}
+}
+
+//NodeValueEquals provides a evaluator for checking the value of the node
+func NodeValueEquals(val interface{}) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if n != nil {
+			return reflect.DeepEqual(n.Value(), val)
+		}
+		return false
+	}
+}
+
+//NodeValueMatch provides a evaluator for checking the value of the node with a function
+func NodeValueMatch(fx func(interface{}) bool) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if n != nil {
+			return fx(n.Value())
+		}
+		return false
+	}
+}
+
+//NodeLabel provides a evaluator for checking the node carries the label
+func NodeLabel(label string) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if n != nil {
+			return n.Labels().Has(label)
+		}
+		return false
+	}
+}
+
+//NodeKeyCompare provides a evaluator comparing the value of a node property against val in the manner of EdgeKeyCompare
+func NodeKeyCompare(key string, op Comparison, val interface{}) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if n == nil || !n.Properties().Has(key) {
+			return false
+		}
+		return op.Compare(n.Properties().Get(key), val)
+	}
+}
+
+//WeightBetween provides a evaluator for checking the socket weight is within min and max inclusive
+func WeightBetween(min, max int) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if soc != nil {
+			return soc.Weight >= min && soc.Weight <= max
+		}
+		return false
+	}
+}
+
+//WeightAtLeast provides a evaluator for checking the socket weight is at least w
+func WeightAtLeast(w int) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if soc != nil {
+			return soc.Weight >= w
+		}
+		return false
+	}
+}
+
+//WeightAtMost provides a evaluator for checking the socket weight is at most w
+func WeightAtMost(w int) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if soc != nil {
+			return soc.Weight <= w
+		}
+		return false
+	}
+}
+
+//EdgeAttrRegexp provides a evaluator for checking any socket attribute matches the expression
+func EdgeAttrRegexp(re *regexp.Regexp) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if soc == nil || soc.Attrs == nil {
+			return false
+		}
+
+		found := false
+
+		soc.Attrs.Each(func(attr string, _ int, stop func()) {
+			if re.MatchString(attr) {
+				found = true
+				stop()
+			}
+		})
+
+		return found
+	}
+}
+
+//EdgeAttrMatch compiles the expression into a EdgeAttrRegexp evaluator
+func EdgeAttrMatch(expr string) (NodeEval, error) {
+	re, err := regexp.Compile(expr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return EdgeAttrRegexp(re), nil
+}
+
+//EdgeKeyCompare provides a evaluator comparing the value of a socket key against val
+func EdgeKeyCompare(key string, op Comparison, val interface{}) NodeEval {
+	return func(n Nodes, soc *Socket, depth int) bool {
+		if soc == nil || !soc.Has(key) {
+			return false
+		}
+		return op.Compare(soc.Get(key), val)
+	}
+}
+
+//Compare returns true if a holds the comparison against b
+func (c Comparison) Compare(a, b interface{}) bool {
+	order, ok := compareValues(a, b)
+
+	if !ok {
+		if c == Equals {
+			return reflect.DeepEqual(a, b)
+		}
+		if c == NotEquals {
+			return !reflect.DeepEqual(a, b)
+		}
+		return false
+	}
+
+	switch c {
+	case Equals:
+		return order == 0
+	case NotEquals:
+		return order != 0
+	case LessThan:
+		return order < 0
+	case LessOrEqual:
+		return order <= 0
+	case GreaterThan:
+		return order > 0
+	case GreaterOrEqual:
+		return order >= 0
+	}
+
+	return false
+}
+
+//compareValues orders two numbers or two strings, returning false if they are of kinds that can not be ordered together
+func compareValues(a, b interface{}) (int, bool) {
+	if as, ok := a.(string); ok {
+		bs, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case as < bs:
+			return -1, true
+		case as > bs:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	af, ok := toFloat(a)
+	if !ok {
+		return 0, false
+	}
+
+	bf, ok := toFloat(b)
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case af < bf:
+		return -1, true
+	case af > bf:
+		return 1, true
+	}
+	return 0, true
+}
+
+//toFloat returns the numeric value of any integer or float kind
+func toFloat(v interface{}) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+
+	return 0, false
+}
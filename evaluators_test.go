@@ -0,0 +1,170 @@
+package ds
+
+import (
+	"fmt"
+	"testing"
+)
+
+func filterValues(t *testing.T, gs *Graph, eval NodeEval) string {
+	builder, err := Filter(BFPreOrderDirective(nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := builder.Evaluator(eval).Transverse(gs.Get(1))
+
+	for filter.Next() == nil {
+	}
+
+	var values []interface{}
+	for _, n := range filter.Nodes() {
+		values = append(values, n.Value())
+	}
+
+	return fmt.Sprint(values)
+}
+
+func TestEvaluatorCombinators(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+
+	connect := func(a, b interface{}, w int, attr string, rank interface{}) {
+		so := gs.Get(a).Connect(gs.Get(b), w)
+		so.Attrs.Add(attr)
+		so.Set("rank", rank)
+	}
+
+	connect(1, 2, 1, "friend", 3)
+	connect(1, 3, 5, "colleague", 7.5)
+	connect(2, 4, 10, "friend-of-friend", int64(12))
+	connect(3, 5, 2, "family", "high")
+
+	friends := EdgeAttr("friend")
+	family := EdgeAttr("family")
+
+	cases := []struct {
+		eval   NodeEval
+		expect string
+	}{
+		{Any(), "[1 2 3 4 5]"},
+		{Not(NodeValueEquals(3)), "[1 2 4]"},
+		{Or(friends, EdgeAttr("colleague")), "[1 2 3]"},
+		{And(Or(NodeValueEquals(3), NodeValueEquals(5)), Not(family)), "[1 3]"},
+		{NodeValueMatch(func(v interface{}) bool { return v.(int)%2 == 0 }), "[1 2 4]"},
+		{WeightBetween(1, 5), "[1 2 3 5]"},
+		{WeightAtLeast(5), "[1 3]"},
+		{WeightAtMost(2), "[1 2]"},
+	}
+
+	for i, c := range cases {
+		if got := filterValues(t, gs, c.eval); got != c.expect {
+			t.Fatalf("Case %d: expected %s got %s", i, c.expect, got)
+		}
+	}
+}
+
+func TestEdgeAttrMatch(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+
+	connect := func(a, b interface{}, w int, attr string, rank interface{}) {
+		so := gs.Get(a).Connect(gs.Get(b), w)
+		so.Attrs.Add(attr)
+		so.Set("rank", rank)
+	}
+
+	connect(1, 2, 1, "friend", 3)
+	connect(1, 3, 5, "colleague", 7.5)
+	connect(2, 4, 10, "friend-of-friend", int64(12))
+	connect(3, 5, 2, "family", "high")
+
+	eval, err := EdgeAttrMatch("^friend")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := filterValues(t, gs, eval); got != "[1 2 4]" {
+		t.Fatalf("Unexpected attribute matches %s", got)
+	}
+
+	if _, err := EdgeAttrMatch("(friend"); err == nil {
+		t.Fatal("Expected a bad expression to fail")
+	}
+}
+
+func TestEdgeKeyCompare(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+
+	connect := func(a, b interface{}, w int, attr string, rank interface{}) {
+		so := gs.Get(a).Connect(gs.Get(b), w)
+		so.Attrs.Add(attr)
+		so.Set("rank", rank)
+	}
+
+	connect(1, 2, 1, "friend", 3)
+	connect(1, 3, 5, "colleague", 7.5)
+	connect(2, 4, 10, "friend-of-friend", int64(12))
+	connect(3, 5, 2, "family", "high")
+
+	cases := []struct {
+		eval   NodeEval
+		expect string
+	}{
+		{EdgeKeyCompare("rank", GreaterThan, 5), "[1 3]"},
+		{EdgeKeyCompare("rank", LessOrEqual, 12.0), "[1 2 3 4]"},
+		{EdgeKeyCompare("rank", Equals, uint8(3)), "[1 2]"},
+		{EdgeKeyCompare("rank", NotEquals, 3), "[1 3 5]"},
+		{EdgeKeyCompare("rank", GreaterOrEqual, "a"), "[1]"},
+		{EdgeKeyCompare("missing", NotEquals, 3), "[1]"},
+	}
+
+	for i, c := range cases {
+		if got := filterValues(t, gs, c.eval); got != c.expect {
+			t.Fatalf("Case %d: expected %s got %s", i, c.expect, got)
+		}
+	}
+
+	if !LessThan.Compare("apple", "banana") || GreaterThan.Compare("a", 1) {
+		t.Fatal("Unexpected string comparison")
+	}
+}
+
+func TestNodeKeyCompare(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+
+	connect := func(a, b interface{}, w int, attr string, rank interface{}) {
+		so := gs.Get(a).Connect(gs.Get(b), w)
+		so.Attrs.Add(attr)
+		so.Set("rank", rank)
+	}
+
+	connect(1, 2, 1, "friend", 3)
+	connect(1, 3, 5, "colleague", 7.5)
+	connect(2, 4, 10, "friend-of-friend", int64(12))
+	connect(3, 5, 2, "family", "high")
+
+	gs.Get(2).Properties().Set("score", 10)
+	gs.Get(3).Properties().Set("score", 2.5)
+	gs.Get(3).Labels().Add("vip")
+	gs.Get(5).Labels().Add("vip")
+
+	cases := []struct {
+		eval   NodeEval
+		expect string
+	}{
+		{NodeKeyCompare("score", GreaterThan, 5), "[1 2]"},
+		{NodeKeyCompare("score", LessOrEqual, 10), "[1 2 3]"},
+		{NodeLabel("vip"), "[1 3 5]"},
+		{And(NodeLabel("vip"), NodeKeyCompare("score", LessThan, 3)), "[1 3]"},
+	}
+
+	for i, c := range cases {
+		if got := filterValues(t, gs, c.eval); got != c.expect {
+			t.Fatalf("Case %d: expected %s got %s", i, c.expect, got)
+		}
+	}
+}
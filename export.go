@@ -0,0 +1,173 @@
+package ds
+
+import (
+	"fmt"
+
+	"github.com/influx6/flux"
+)
+
+//NodeData holds a node of an exported graph
+type NodeData struct {
+	ID         string
+	Value      interface{}
+	Labels     []string
+	Properties map[string]interface{}
+}
+
+//EdgeData holds a socket of an exported graph, From and To are node ids
+type EdgeData struct {
+	ID         string
+	From, To   string
+	Weight     int
+	Attrs      []string
+	Properties map[string]interface{}
+}
+
+//GraphData holds a graph in a form ready for encoding, node and socket ids are kept so references to them survive a round trip
+type GraphData struct {
+	UID   string
+	Nodes []NodeData
+	Edges []EdgeData
+}
+
+//exportCollector returns the entries of the collector keyed by their printed keys
+func exportCollector(c flux.Collector) map[string]interface{} {
+	var props map[string]interface{}
+
+	c.Each(func(k, v interface{}) {
+		if props == nil {
+			props = make(map[string]interface{})
+		}
+		props[fmt.Sprint(k)] = v
+	})
+
+	return props
+}
+
+//Export returns the nodes and sockets of the graph as GraphData in graph order, leaving out sockets leading out of the graph and printing property keys as strings
+func (n *Graph) Export() *GraphData {
+	data := &GraphData{UID: n.uid}
+
+	nodes := n.nodes.AllNodes()
+	held := VisitMaps()
+
+	for _, nx := range nodes {
+		held[nx] = true
+		data.Nodes = append(data.Nodes, NodeData{
+			ID:         nx.ID(),
+			Value:      nx.Value(),
+			Labels:     nx.Labels().All(),
+			Properties: exportCollector(nx.Properties()),
+		})
+	}
+
+	for _, nx := range nodes {
+		for _, sc := range OutSockets(nx) {
+			if !held[sc.To] {
+				continue
+			}
+
+			data.Edges = append(data.Edges, EdgeData{
+				ID:         sc.ID,
+				From:       nx.ID(),
+				To:         sc.To.ID(),
+				Weight:     sc.Weight,
+				Attrs:      sc.Attrs.All(),
+				Properties: exportCollector(sc.Collector),
+			})
+		}
+	}
+
+	return data
+}
+
+//ImportGraph returns a new graph built from the data, keeping the uid, node ids and socket ids it holds
+func ImportGraph(data *GraphData) (*Graph, error) {
+	g := NewGraph()
+
+	if data == nil {
+		return g, nil
+	}
+
+	if data.UID != "" {
+		g.uid = data.UID
+	}
+
+	for _, nd := range data.Nodes {
+		nx, err := g.AddWithID(nd.ID, nd.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, label := range nd.Labels {
+			nx.Labels().Add(label)
+		}
+
+		for k, v := range nd.Properties {
+			nx.Properties().Set(k, v)
+		}
+	}
+
+	for _, ed := range data.Edges {
+		from, to := g.NodeByID(ed.From), g.NodeByID(ed.To)
+
+		if from == nil || to == nil {
+			return nil, ErrNotFound
+		}
+
+		sc := from.Connect(to, ed.Weight)
+
+		if ed.ID != "" {
+			sc.ID = ed.ID
+		}
+
+		for _, attr := range ed.Attrs {
+			sc.Attrs.Add(attr)
+		}
+
+		for k, v := range ed.Properties {
+			sc.Set(k, v)
+		}
+	}
+
+	return g, nil
+}
+
+//Clone returns a new graph holding copies of the nodes and sockets of this graph with the same ids
+func (n *Graph) Clone() *Graph {
+	g := NewGraph()
+
+	nodes := n.nodes.AllNodes()
+	copies := make(map[Nodes]Nodes, len(nodes))
+
+	for _, nx := range nodes {
+		c := NewGraphNodeWithID(nx.ID(), nx.Value(), g)
+
+		nx.Labels().EachString(c.Attrs.Add)
+		nx.Properties().Each(func(k, v interface{}) {
+			c.Set(k, v)
+		})
+
+		g.AddNode(c)
+		copies[nx] = c
+	}
+
+	for _, nx := range nodes {
+		for _, sc := range OutSockets(nx) {
+			to, ok := copies[sc.To]
+			if !ok {
+				continue
+			}
+
+			cs := copies[nx].Connect(to, sc.Weight)
+			cs.ID = sc.ID
+
+			sc.Attrs.EachString(cs.Attrs.Add)
+			sc.Each(func(k, v interface{}) {
+				cs.Set(k, v)
+			})
+		}
+	}
+
+	return g
+}
@@ -0,0 +1,203 @@
+package ds
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func edgeString(sc *Socket) string {
+	return fmt.Sprintf("%v>%v:%d:%v:%v", sc.From.Value(), sc.To.Value(), sc.Weight, sc.Attrs.All(), sc.Get("since"))
+}
+
+func TestGraphIDs(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("api", "db")
+
+	cache, _ := gs.AddWithID("cache-1", "cache")
+
+	so := gs.Get("api").Connect(cache, 3)
+	so.ID = "api-cache"
+
+	cache.Connect(gs.Get("db"), 1)
+
+	if _, err := gs.AddWithID("cache-1", "other"); err != ErrDuplicateID {
+		t.Fatalf("Expected ErrDuplicateID got %v", err)
+	}
+
+	if _, err := gs.AddWithID("x", "api"); err != ErrDuplicateValue || gs.NodeByID("x") != nil {
+		t.Fatalf("Expected ErrDuplicateValue and no node under x got %v", err)
+	}
+
+	if n := gs.NodeByID("cache-1"); n == nil || n.Value() != "cache" {
+		t.Fatalf("Expected the cache node got %v", n)
+	}
+
+	api := gs.Get("api")
+	if api.ID() == "" || gs.NodeByID(api.ID()) != api {
+		t.Fatalf("Expected api to have a generated id found by NodeByID")
+	}
+
+	sc := gs.EdgeByID("api-cache")
+	if sc == nil || sc.From != api {
+		t.Fatalf("Expected the api socket got %v", sc)
+	}
+
+	db := OutSockets(gs.Get("cache"))[0]
+	if db.ID == "" || gs.EdgeByID(db.ID) != db {
+		t.Fatal("Expected a generated socket id found by EdgeByID")
+	}
+
+	old := db.ID
+	db.ID = "cache-db"
+
+	if gs.EdgeByID("cache-db") != db || gs.EdgeByID(old) != nil {
+		t.Fatal("Expected a renamed socket to be found under its new id")
+	}
+
+	gs.Remove("cache")
+
+	if gs.NodeByID("cache-1") != nil || gs.EdgeByID("api-cache") != nil {
+		t.Fatal("Expected removed node and its sockets to be gone")
+	}
+}
+
+func TestGraphClone(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("api", "db")
+
+	cache, _ := gs.AddWithID("cache-1", "cache")
+	cache.Labels().Add("store")
+	cache.Properties().Set("ttl", 30)
+
+	so := gs.Get("api").Connect(cache, 3)
+	so.ID = "api-cache"
+	so.Attrs.Add("reads")
+	so.Set("since", 2020)
+
+	cache.Connect(gs.Get("db"), 1)
+	gs.Get("db").Connect(NewGraphNode("remote", nil), 1)
+	clone := gs.Clone()
+
+	if clone.UID() == gs.UID() || clone.Length() != gs.Length() {
+		t.Fatalf("Expected a new graph of %d nodes got %d", gs.Length(), clone.Length())
+	}
+
+	for _, n := range gs.nodeSet().AllNodes() {
+		c := clone.NodeByID(n.ID())
+
+		if c == nil || c == n || c.Value() != n.Value() || c.Graph() != Graphs(clone) {
+			t.Fatalf("Expected a copy of %v in the clone got %v", n, c)
+		}
+	}
+
+	c := clone.EdgeByID("api-cache")
+	if c == nil || c == gs.EdgeByID("api-cache") {
+		t.Fatal("Expected a copy of the api socket")
+	}
+
+	if got := edgeString(c); got != "api>cache:3:[reads]:2020" {
+		t.Fatalf("Unexpected cloned socket %s", got)
+	}
+
+	c.Set("since", 1999)
+	if gs.EdgeByID("api-cache").Get("since") != 2020 {
+		t.Fatal("Changing the clone changed the original")
+	}
+
+	if len(OutSockets(clone.NodeByID(gs.Get("db").ID()))) != 0 {
+		t.Fatal("Expected the socket leading out of the graph to be left behind")
+	}
+
+	if node := clone.NodeByID("cache-1"); !node.Labels().Has("store") || node.Properties().Get("ttl") != 30 {
+		t.Fatal("Expected labels and properties to be copied")
+	}
+}
+
+func TestGraphExportImport(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("api", "db")
+
+	cache, _ := gs.AddWithID("cache-1", "cache")
+	cache.Labels().Add("store")
+	cache.Properties().Set("ttl", 30)
+
+	so := gs.Get("api").Connect(cache, 3)
+	so.ID = "api-cache"
+	so.Attrs.Add("reads")
+	so.Set("since", 2020)
+
+	cache.Connect(gs.Get("db"), 1)
+	gs.Get("db").Connect(NewGraphNode("remote", nil), 1)
+
+	data, err := json.Marshal(gs.Export())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stored GraphData
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := ImportGraph(&stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if imported.UID() != gs.UID() || imported.Length() != gs.Length() {
+		t.Fatalf("Expected uid %s and %d nodes", gs.UID(), gs.Length())
+	}
+
+	for _, n := range gs.nodeSet().AllNodes() {
+		in := imported.NodeByID(n.ID())
+		if in == nil || in.Value() != n.Value() {
+			t.Fatalf("Expected node %v under id %s got %v", n, n.ID(), in)
+		}
+
+		for _, sc := range OutSockets(n) {
+			isc := imported.EdgeByID(sc.ID)
+
+			if sc.To.Value() == "remote" {
+				if isc != nil {
+					t.Fatal("Expected the socket leading out of the graph to be left out")
+				}
+				continue
+			}
+
+			if isc == nil || isc.From != in || isc.To.ID() != sc.To.ID() {
+				t.Fatalf("Expected socket %s under id %s", edgeString(sc), sc.ID)
+			}
+		}
+	}
+
+	if got := edgeString(imported.EdgeByID("api-cache")); got != "api>cache:3:[reads]:2020" {
+		t.Fatalf("Unexpected imported socket %s", got)
+	}
+
+	if node := imported.NodeByID("cache-1"); !node.Labels().Has("store") || node.Properties().Get("ttl") != 30.0 {
+		t.Fatal("Expected labels and properties to be imported")
+	}
+
+	gs.Get("api").Properties().Set(7, "seven")
+
+	if keyed, _ := ImportGraph(gs.Export()); keyed.Get("api").Properties().Get("7") != "seven" {
+		t.Fatal("Expected a non-string property key to come back printed as a string")
+	}
+
+	stored.Edges = append(stored.Edges, EdgeData{From: "cache-1", To: "missing"})
+	if _, err := ImportGraph(&stored); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for a dangling socket got %v", err)
+	}
+
+	stored.Edges = nil
+	stored.Nodes = append(stored.Nodes, NodeData{ID: "cache-1", Value: "copy"})
+	if _, err := ImportGraph(&stored); err != ErrDuplicateID {
+		t.Fatalf("Expected ErrDuplicateID got %v", err)
+	}
+
+	stored.Nodes[len(stored.Nodes)-1] = NodeData{ID: "copy-1", Value: "cache"}
+	if _, err := ImportGraph(&stored); err != ErrDuplicateValue {
+		t.Fatalf("Expected ErrDuplicateValue got %v", err)
+	}
+}
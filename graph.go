@@ -2,7 +2,9 @@ package ds
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
 
 	"code.google.com/p/go-uuid/uuid"
 
@@ -18,6 +20,8 @@ type Values interface {
 type Nodes interface {
 	Values
 	Equalers
+	//ID returns the stable id of the node
+	ID() string
 	//Represents the archs/edges of this nodes
 	Sockets() *DeferList
 	//Bind the supplied node to this node
@@ -32,13 +36,18 @@ type Nodes interface {
 	ChangeGraph(Graphs)
 	//Graph returns the graph of the node
 	Graph() Graphs
+	//Properties returns the key/value properties of the node
+	Properties() flux.Collector
+	//Labels returns the labels of the node
+	Labels() *StringSet
 	Arcs() DeferIterator
 	String() string
 }
 
-//Socket represents a connection between two nodes
+//Socket represents a connection between two nodes, ID is generated on creation and may be replaced by a user supplied one
 type Socket struct {
 	flux.Collector
+	ID     string
 	Attrs  *StringSet
 	To     Nodes
 	From   Nodes
@@ -49,6 +58,7 @@ type Socket struct {
 func NewSocket(from, to Nodes, weight int) *Socket {
 	return &Socket{
 		Collector: flux.NewCollector(),
+		ID:        uuid.New(),
 		Attrs:     NewStringSet(),
 		To:        to,
 		From:      from,
@@ -64,16 +74,28 @@ func (s *Socket) Close() {
 	s.Clear()
 }
 
-//Node represents an element in the graph
+//Node represents an element in the graph, the collector holds its properties and Attrs its labels
 type Node struct {
+	flux.Collector
+	Attrs *StringSet
+	id    string
 	data  interface{}
 	arcs  *DeferList
 	graph Graphs
 }
 
+//ID returns the stable id of the node
+func (n *Node) ID() string {
+	return n.id
+}
+
 //ChangeValue changes the value of the node
 func (n *Node) ChangeValue(d interface{}) {
 	n.data = d
+
+	if g, ok := n.graph.(*Graph); ok {
+		g.revalue(n)
+	}
 }
 
 //ChangeGraph changes the graph this nodes is attached to
@@ -100,6 +122,16 @@ func (n *Node) Graph() Graphs {
 	return n.graph
 }
 
+//Properties returns the key/value properties of the node
+func (n *Node) Properties() flux.Collector {
+	return n.Collector
+}
+
+//Labels returns the labels of the node
+func (n *Node) Labels() *StringSet {
+	return n.Attrs
+}
+
 //Equals returns bool if the value is equal
 func (n *Node) Equals(d interface{}) bool {
 	dx, ok := d.(Nodes)
@@ -214,15 +246,28 @@ func (n *Node) Connect(r Nodes, weight int) *Socket {
 	socket = NewSocket(n, r, weight)
 	// _ = r.Connect(n, weight)
 	n.arcs.AppendElement(socket)
+
+	if g, ok := n.graph.(*Graph); ok {
+		g.trackSocket(socket)
+	}
+
 	return socket
 }
 
-//NewGraphNode returns a new graph node
+//NewGraphNode returns a new graph node with a generated id
 func NewGraphNode(d interface{}, g Graphs) *Node {
+	return NewGraphNodeWithID(uuid.New(), d, g)
+}
+
+//NewGraphNodeWithID returns a new graph node with the giving id
+func NewGraphNodeWithID(id string, d interface{}, g Graphs) *Node {
 	return &Node{
-		data:  d,
-		arcs:  List(),
-		graph: g,
+		Collector: flux.NewCollector(),
+		Attrs:     NewStringSet(),
+		id:        id,
+		data:      d,
+		arcs:      List(),
+		graph:     g,
 	}
 }
 
@@ -256,6 +301,14 @@ type Graphs interface {
 	BindNodes(Nodes, Nodes, int) (*Socket, bool)
 	UnBindNodes(Nodes, Nodes) bool
 	IsBound(interface{}, interface{}) bool
+	Remove(...interface{})
+	RemoveNode(Nodes)
+	OnAdd(UseNode)
+	OnRemove(UseNode)
+	NodesByLabel(string) []Nodes
+	NodesByProperty(string, interface{}) []Nodes
+	NodeByID(string) Nodes
+	EdgeByID(string) *Socket
 	nodeSet() *NodeSet
 	Length() int
 	// UnBindAll(interface{}, interface{}) bool
@@ -263,8 +316,16 @@ type Graphs interface {
 
 //Graph represent a standard structure of nodes
 type Graph struct {
-	nodes *NodeSet
-	uid   string
+	nodes   *NodeSet
+	uid     string
+	ids     map[string]Nodes
+	values  map[interface{}]Nodes
+	keys    map[Nodes]interface{}
+	foreign map[Nodes]bool
+	sockets map[string]*Socket
+	ro      sync.RWMutex
+	adds    []UseNode
+	removes []UseNode
 }
 
 //Length returns the size of the graph
@@ -284,17 +345,25 @@ func (n *Graph) Contains(r interface{}) bool {
 	return f
 }
 
-//AddNode as a node into the graph and sets the node graph to this graph,thereby clearing all previos connection
+//AddNode as a node into the graph and sets the node graph to this graph,thereby clearing all previos connection, nodes whose value is already in the graph are rejected
 func (n *Graph) AddNode(r Nodes) {
+	if n.has(r.Value()) {
+		return
+	}
+
 	r.ChangeGraph(n)
 	n.nodes.AddNode(r)
+	n.track(r)
+	n.notify(false, r)
 }
 
 //AddForeignNode as a node into the graph without setting the node graph to this graph,thereby clearing all previos connection but note if this nodes value is the same with another node in this,this will be rejected
 func (n *Graph) AddForeignNode(r Nodes) {
-	if !n.Contains(r.Value()) {
+	if !n.has(r.Value()) {
 		// r.ChangeGraph(n)
 		n.nodes.AddNode(r)
+		n.track(r)
+		n.notify(false, r)
 	}
 }
 
@@ -320,7 +389,358 @@ func (n *Graph) String() string {
 //Add as a new node into the graph
 func (n *Graph) Add(r ...interface{}) {
 	for _, v := range r {
-		n.nodes.AddNode(NewGraphNode(v, n))
+		if n.has(v) {
+			continue
+		}
+
+		nx := NewGraphNode(v, n)
+		n.nodes.AddNode(nx)
+		n.track(nx)
+		n.notify(false, nx)
+	}
+}
+
+//AddWithID adds the value into the graph as a node with the giving id, returning ErrDuplicateID or ErrDuplicateValue if the graph already holds a node of that id or value
+func (n *Graph) AddWithID(id string, v interface{}) (Nodes, error) {
+	if n.NodeByID(id) != nil {
+		return nil, ErrDuplicateID
+	}
+
+	if n.has(v) {
+		return nil, ErrDuplicateValue
+	}
+
+	nx := NewGraphNodeWithID(id, v, n)
+	n.nodes.AddNode(nx)
+	n.track(nx)
+	n.notify(false, nx)
+
+	return nx, nil
+}
+
+//NodeByID returns the node of the graph with the id, nil if there is none
+func (n *Graph) NodeByID(id string) Nodes {
+	n.ro.RLock()
+	defer n.ro.RUnlock()
+	return n.ids[id]
+}
+
+//EdgeByID returns the socket of the graph with the id, nil if there is none
+func (n *Graph) EdgeByID(id string) *Socket {
+	n.ro.RLock()
+	sc := n.sockets[id]
+	n.ro.RUnlock()
+
+	if sc != nil && sc.ID == id && n.holds(sc) {
+		return sc
+	}
+
+	return n.reindexSockets()[id]
+}
+
+//holds returns true if the socket still leaves a node of the graph
+func (n *Graph) holds(sc *Socket) bool {
+	if sc.From == nil || n.NodeByID(sc.From.ID()) != sc.From {
+		return false
+	}
+
+	for _, so := range OutSockets(sc.From) {
+		if so == sc {
+			return true
+		}
+	}
+
+	return false
+}
+
+//reindexSockets rebuilds the socket ids from the nodes of the graph, picking up ids changed since their sockets were connected
+func (n *Graph) reindexSockets() map[string]*Socket {
+	sockets := make(map[string]*Socket)
+
+	n.nodes.EachNode(func(nx Nodes) {
+		for _, sc := range OutSockets(nx) {
+			if _, ok := sockets[sc.ID]; !ok {
+				sockets[sc.ID] = sc
+			}
+		}
+	})
+
+	n.ro.Lock()
+	n.sockets = sockets
+	n.ro.Unlock()
+
+	return sockets
+}
+
+//trackSocket records the id of the socket
+func (n *Graph) trackSocket(sc *Socket) {
+	n.ro.Lock()
+	defer n.ro.Unlock()
+
+	if _, ok := n.sockets[sc.ID]; !ok {
+		n.sockets[sc.ID] = sc
+	}
+}
+
+//untrackSocket drops the id of the socket
+func (n *Graph) untrackSocket(sc *Socket) {
+	n.ro.Lock()
+	defer n.ro.Unlock()
+
+	if n.sockets[sc.ID] == sc {
+		delete(n.sockets, sc.ID)
+	}
+}
+
+//track records the id and value of the node, an id or value already held by another node of the graph keeps pointing at that node
+func (n *Graph) track(r Nodes) {
+	n.ro.Lock()
+	defer n.ro.Unlock()
+
+	if _, ok := n.ids[r.ID()]; !ok {
+		n.ids[r.ID()] = r
+	}
+
+	if r.Graph() != Graphs(n) {
+		n.foreign[r] = true
+	}
+
+	n.index(r)
+}
+
+//index records the value of the node if it can key a map, the lock must be held
+func (n *Graph) index(r Nodes) {
+	v := r.Value()
+
+	if !hashable(v) {
+		return
+	}
+
+	if _, ok := n.values[v]; !ok {
+		n.values[v] = r
+		n.keys[r] = v
+	}
+}
+
+//unindex drops the value recorded for the node, the lock must be held
+func (n *Graph) unindex(r Nodes) {
+	if k, ok := n.keys[r]; ok {
+		delete(n.values, k)
+		delete(n.keys, r)
+	}
+}
+
+//revalue moves the node to its new value in the value index
+func (n *Graph) revalue(r Nodes) {
+	n.ro.Lock()
+	defer n.ro.Unlock()
+
+	if _, ok := n.keys[r]; !ok && n.ids[r.ID()] != r {
+		return
+	}
+
+	n.unindex(r)
+	n.index(r)
+}
+
+//has returns true if the graph holds a node of the value, values which can key a map are looked up without walking the nodes
+func (n *Graph) has(v interface{}) bool {
+	if !hashable(v) {
+		return n.Contains(v)
+	}
+
+	n.ro.Lock()
+	defer n.ro.Unlock()
+
+	if nx, ok := n.values[v]; ok {
+		if !n.foreign[nx] || sameValue(nx.Value(), v) {
+			return true
+		}
+
+		n.unindex(nx)
+	}
+
+	for nx := range n.foreign {
+		if sameValue(nx.Value(), v) {
+			n.unindex(nx)
+			n.index(nx)
+			return true
+		}
+	}
+
+	return false
+}
+
+//sameValue returns true if both values are equal, values which can not be compared are never the same
+func sameValue(a, b interface{}) bool {
+	return hashable(a) && hashable(b) && a == b
+}
+
+//hashable returns true if the value can key a map without panicking, interfaces within structs and arrays are checked by what they hold
+func hashable(v interface{}) bool {
+	return v != nil && hashableValue(reflect.ValueOf(v))
+}
+
+func hashableValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		return v.IsNil() || hashableValue(v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !hashableValue(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !hashableValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Map, reflect.Func:
+		return false
+	}
+
+	return true
+}
+
+//Remove removes the nodes of these values from the graph along with their sockets
+func (n *Graph) Remove(r ...interface{}) {
+	for _, v := range r {
+		if nx, ok := n.nodes.GetNode(v); ok {
+			n.RemoveNode(nx)
+		}
+	}
+}
+
+//RemoveNode removes the node from the graph, dropping its sockets and those of other nodes leading into it
+func (n *Graph) RemoveNode(r Nodes) {
+	if r == nil {
+		return
+	}
+
+	r, ok := n.nodes.GetNode(r)
+	if !ok {
+		return
+	}
+
+	n.nodes.RemoveNode(r)
+
+	n.ro.Lock()
+	if n.ids[r.ID()] == r {
+		delete(n.ids, r.ID())
+	}
+
+	n.unindex(r)
+	delete(n.foreign, r)
+	n.ro.Unlock()
+
+	n.nodes.EachNode(func(nx Nodes) {
+		dropSockets(nx, r)
+	})
+
+	for _, sc := range OutSockets(r) {
+		n.untrackSocket(sc)
+	}
+
+	r.Sockets().Clear()
+
+	if r.Graph() == n {
+		r.ChangeGraph(nil)
+	}
+
+	n.notify(true, r)
+}
+
+//NodesByLabel returns the nodes of the graph carrying the label
+func (n *Graph) NodesByLabel(label string) []Nodes {
+	var nodes []Nodes
+
+	n.nodes.EachNode(func(nx Nodes) {
+		if nx.Labels().Has(label) {
+			nodes = append(nodes, nx)
+		}
+	})
+
+	return nodes
+}
+
+//NodesByProperty returns the nodes of the graph whose property of the key equals the value
+func (n *Graph) NodesByProperty(key string, val interface{}) []Nodes {
+	var nodes []Nodes
+
+	n.nodes.EachNode(func(nx Nodes) {
+		props := nx.Properties()
+		if props.Has(key) && Equals.Compare(props.Get(key), val) {
+			nodes = append(nodes, nx)
+		}
+	})
+
+	return nodes
+}
+
+//OnAdd registers a function called with every node added into the graph
+func (n *Graph) OnAdd(fx UseNode) {
+	if fx == nil {
+		return
+	}
+
+	n.ro.Lock()
+	n.adds = append(n.adds, fx)
+	n.ro.Unlock()
+}
+
+//OnRemove registers a function called with every node removed from the graph
+func (n *Graph) OnRemove(fx UseNode) {
+	if fx == nil {
+		return
+	}
+
+	n.ro.Lock()
+	n.removes = append(n.removes, fx)
+	n.ro.Unlock()
+}
+
+//notify calls the functions registered for additions or removals with the node
+func (n *Graph) notify(removed bool, r Nodes) {
+	n.ro.RLock()
+	hooks := n.adds
+	if removed {
+		hooks = n.removes
+	}
+	n.ro.RUnlock()
+
+	for _, fx := range hooks {
+		fx(r)
+	}
+}
+
+//dropSockets removes the sockets of the node leading to the target, keeping the others in order
+func dropSockets(n, to Nodes) {
+	socks := OutSockets(n)
+	kept := socks[:0]
+
+	g, _ := n.Graph().(*Graph)
+
+	for _, sc := range socks {
+		if sc.To != to {
+			kept = append(kept, sc)
+		} else if g != nil {
+			g.untrackSocket(sc)
+		}
+	}
+
+	if len(kept) == len(socks) {
+		return
+	}
+
+	arcs := n.Sockets()
+	arcs.Clear()
+
+	for _, sc := range kept {
+		arcs.AppendElement(sc)
 	}
 }
 
@@ -388,9 +808,70 @@ func (n *Graph) IsBound(r, f interface{}) bool {
 //NewGraph returns a new graph instance
 func NewGraph() *Graph {
 	return &Graph{
-		nodes: NewNodeSet(),
-		uid:   uuid.New(),
+		nodes:   NewNodeSet(),
+		uid:     uuid.New(),
+		ids:     make(map[string]Nodes),
+		values:  make(map[interface{}]Nodes),
+		keys:    make(map[Nodes]interface{}),
+		foreign: make(map[Nodes]bool),
+		sockets: make(map[string]*Socket),
+	}
+}
+
+//OutSockets returns the sockets leaving the giving node
+func OutSockets(n Nodes) []*Socket {
+	var socks []*Socket
+
+	itr := n.Arcs()
+
+	for itr.Next() == nil {
+		sock, ok := itr.Value().(*Socket)
+		if ok {
+			socks = append(socks, sock)
+		}
+	}
+
+	return socks
+}
+
+//InSockets returns the sockets within the graph that point into the giving node
+func InSockets(g Graphs, n Nodes) []*Socket {
+	var socks []*Socket
+
+	g.nodeSet().EachNode(func(nx Nodes) {
+		for _, sock := range OutSockets(nx) {
+			if sock.To == n {
+				socks = append(socks, sock)
+			}
+		}
+	})
+
+	return socks
+}
+
+//incomingSockets returns the sockets pointing into each node of the graph
+func incomingSockets(g Graphs) map[Nodes][]*Socket {
+	incoming := make(map[Nodes][]*Socket)
+
+	if g == nil {
+		return incoming
 	}
+
+	g.nodeSet().EachNode(func(nx Nodes) {
+		for _, sock := range OutSockets(nx) {
+			incoming[sock.To] = append(incoming[sock.To], sock)
+		}
+	})
+
+	return incoming
+}
+
+//peer returns the node at the other end of the socket from the giving node
+func peer(s *Socket, n Nodes) Nodes {
+	if s.To == n {
+		return s.From
+	}
+	return s.To
 }
 
 //UnvisitedUtil returns the current set of unvisited nodes
@@ -498,6 +979,11 @@ func (d *NodeCaches) AddCache(n Nodes) {
 	*d = append(*d, NewNodeCache(n))
 }
 
+//addNodeCache adds a prepared node cache to the cache
+func (d *NodeCaches) addNodeCache(n *NodeCache) {
+	*d = append(*d, n)
+}
+
 //VisitMaps returns a new NodeCache
 func VisitMaps() NodeMaps {
 	return make(NodeMaps)
@@ -1,6 +1,9 @@
 package ds
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestGraph(t *testing.T) {
 	gs := NewGraph()
@@ -33,3 +36,157 @@ func TestGraph(t *testing.T) {
 		t.Fatal("'john' is not bound to 'alex'")
 	}
 }
+
+func TestGraphRemove(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("alex", "john", "Block", "Date")
+
+	gs.Bind("alex", "john", 1)
+	gs.Bind("alex", "Block", 1)
+	gs.Bind("john", "Block", 1)
+	gs.Bind("Block", "Date", 1)
+
+	var added, removed []string
+
+	gs.OnAdd(func(n Nodes) { added = append(added, n.String()) })
+	gs.OnRemove(func(n Nodes) { removed = append(removed, n.String()) })
+
+	block := gs.Get("Block")
+	gs.Remove("Block", "missing")
+
+	if gs.Contains("Block") || gs.Length() != 3 {
+		t.Fatalf("'Block' was not removed, graph holds %d nodes", gs.Length())
+	}
+
+	if len(OutSockets(block)) != 0 || block.Graph() != nil {
+		t.Fatal("Removed node kept its sockets or graph")
+	}
+
+	if !gs.IsBound("alex", "john") {
+		t.Fatal("'alex' lost its binding to 'john'")
+	}
+
+	if n := len(OutSockets(gs.Get("alex"))); n != 1 {
+		t.Fatalf("'alex' should keep 1 socket, has %d", n)
+	}
+
+	if n := len(OutSockets(gs.Get("john"))); n != 0 {
+		t.Fatalf("'john' should keep no socket, has %d", n)
+	}
+
+	gs.Add("Echo")
+
+	if fmt.Sprint(added) != "[Echo]" || fmt.Sprint(removed) != "[Block]" {
+		t.Fatalf("Unexpected hooks calls, added %v removed %v", added, removed)
+	}
+}
+
+func TestGraphProperties(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("alex", "john", "Block", "Date")
+
+	gs.Get("alex").Labels().Add("person")
+	gs.Get("john").Labels().Add("person")
+	gs.Get("Block").Labels().Add("place")
+
+	gs.Get("alex").Properties().Set("age", 31)
+	gs.Get("john").Properties().Set("age", int64(31))
+	gs.Get("Date").Properties().Set("age", 2)
+
+	values := func(nodes []Nodes) string {
+		var vals []interface{}
+		for _, n := range nodes {
+			vals = append(vals, n.Value())
+		}
+		return fmt.Sprint(vals)
+	}
+
+	if got := values(gs.NodesByLabel("person")); got != "[alex john]" {
+		t.Fatalf("Expected [alex john] labeled person, got %s", got)
+	}
+
+	if got := values(gs.NodesByProperty("age", 31.0)); got != "[alex john]" {
+		t.Fatalf("Expected [alex john] aged 31, got %s", got)
+	}
+
+	if got := gs.NodesByLabel("thing"); got != nil {
+		t.Fatalf("Expected no node labeled thing, got %v", got)
+	}
+}
+
+func TestGraphRemoveStored(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("alex", "john", "alex")
+
+	other := NewGraph()
+	other.Add("alex", "john")
+
+	gs.Bind("alex", "john", 1)
+	other.Bind("alex", "john", 1)
+
+	if gs.Length() != 2 {
+		t.Fatalf("Expected the duplicate value to be rejected, graph holds %d nodes", gs.Length())
+	}
+
+	var removed []Nodes
+	gs.OnRemove(func(n Nodes) { removed = append(removed, n) })
+
+	stored := gs.Get("john")
+	gs.RemoveNode(other.Get("john"))
+
+	if gs.Contains("john") || len(removed) != 1 || removed[0] != stored {
+		t.Fatal("Expected the stored 'john' to be removed and reported")
+	}
+
+	if len(OutSockets(gs.Get("alex"))) != 0 {
+		t.Fatal("Expected the socket into the removed node to be dropped")
+	}
+
+	if !other.IsBound("alex", "john") || other.Get("john").Graph() != Graphs(other) {
+		t.Fatal("Expected the other graph to be left untouched")
+	}
+}
+
+func TestGraphChangeValue(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("alex", "john")
+
+	gs.Get("alex").(*Node).ChangeValue("max")
+	gs.Add("alex", "max")
+
+	if gs.Length() != 3 || !gs.Contains("alex") {
+		t.Fatalf("Expected the old value to be free and the new one taken, graph holds %d nodes", gs.Length())
+	}
+
+	gs.Remove("max")
+	gs.Add("max")
+
+	if gs.Length() != 3 || !gs.Contains("max") {
+		t.Fatal("Expected the changed value to be added back once removed")
+	}
+
+	other := NewGraph()
+	other.Add("sam")
+
+	sam := other.Get("sam")
+	gs.AddForeignNode(sam)
+	sam.(*Node).ChangeValue("tom")
+
+	if _, err := gs.AddWithID("x", "tom"); err != ErrDuplicateValue {
+		t.Fatalf("Expected the changed foreign value to be taken got %v", err)
+	}
+
+	if _, err := gs.AddWithID("y", "sam"); err != nil {
+		t.Fatalf("Expected the old foreign value to be free got %v", err)
+	}
+
+	type boxed struct {
+		v interface{}
+	}
+
+	gs.Add(boxed{[]int{1}})
+
+	if gs.Length() != 6 {
+		t.Fatalf("Expected a struct holding a slice to be added got %d nodes", gs.Length())
+	}
+}
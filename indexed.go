@@ -0,0 +1,282 @@
+package ds
+
+import (
+	"sort"
+	"sync"
+)
+
+//NodeIndexer returns the key a node is indexed by, nodes with a nil or incomparable key are left out of the index
+type NodeIndexer func(Nodes) interface{}
+
+//nodeIndex holds the nodes of a graph grouped by the key of an indexer
+type nodeIndex struct {
+	by     NodeIndexer
+	keys   map[interface{}][]Nodes
+	nodes  map[Nodes]interface{}
+	sorted []interface{}
+	dirty  bool
+}
+
+//indexKey returns the key stored for a value, numbers are kept as float64 so that keys of different number types meet
+func indexKey(v interface{}) (interface{}, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	if f, ok := toFloat(v); ok {
+		return f, true
+	}
+
+	if !hashable(v) {
+		return nil, false
+	}
+
+	return v, true
+}
+
+//keyRank returns the class of a key within the order of an index, numbers before strings, -1 for keys without an order
+func keyRank(k interface{}) int {
+	switch k.(type) {
+	case float64:
+		return 0
+	case string:
+		return 1
+	}
+	return -1
+}
+
+//keyLess orders the keys of an index
+func keyLess(a, b interface{}) bool {
+	ra, rb := keyRank(a), keyRank(b)
+	if ra != rb {
+		return ra < rb
+	}
+
+	c, _ := compareValues(a, b)
+	return c < 0
+}
+
+func (x *nodeIndex) add(n Nodes) {
+	k, ok := indexKey(x.by(n))
+	if !ok {
+		return
+	}
+
+	if _, ok := x.keys[k]; !ok {
+		x.dirty = true
+	}
+
+	x.keys[k] = append(x.keys[k], n)
+	x.nodes[n] = k
+}
+
+func (x *nodeIndex) remove(n Nodes) {
+	k, ok := x.nodes[n]
+	if !ok {
+		return
+	}
+
+	delete(x.nodes, n)
+
+	bucket := x.keys[k]
+	for i, nx := range bucket {
+		if nx == n {
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			break
+		}
+	}
+
+	if len(bucket) == 0 {
+		delete(x.keys, k)
+		x.dirty = true
+		return
+	}
+
+	x.keys[k] = bucket
+}
+
+//ordered returns the ranged keys of the index in order
+func (x *nodeIndex) ordered() []interface{} {
+	if !x.dirty {
+		return x.sorted
+	}
+
+	x.sorted = x.sorted[:0]
+
+	for k := range x.keys {
+		if keyRank(k) >= 0 {
+			x.sorted = append(x.sorted, k)
+		}
+	}
+
+	sort.Slice(x.sorted, func(i, j int) bool {
+		return keyLess(x.sorted[i], x.sorted[j])
+	})
+
+	x.dirty = false
+	return x.sorted
+}
+
+//IndexedGraphSearch provides a GraphSearcher with secondary indexes that follow the nodes of its graph
+type IndexedGraphSearch struct {
+	*LinearGraphSearch
+	indexes map[string]*nodeIndex
+	ro      sync.Mutex
+}
+
+//NewIndexedGraphSearch returns a new IndexedGraphSearch following the graph
+func NewIndexedGraphSearch(g Graphs) *IndexedGraphSearch {
+	s := &IndexedGraphSearch{
+		LinearGraphSearch: NewLinearGraphSearch(g),
+		indexes:           make(map[string]*nodeIndex),
+	}
+
+	if g != nil {
+		g.OnAdd(s.added)
+		g.OnRemove(s.removed)
+	}
+
+	return s
+}
+
+func (s *IndexedGraphSearch) added(n Nodes) {
+	s.ro.Lock()
+	defer s.ro.Unlock()
+
+	for _, x := range s.indexes {
+		x.add(n)
+	}
+}
+
+func (s *IndexedGraphSearch) removed(n Nodes) {
+	s.ro.Lock()
+	defer s.ro.Unlock()
+
+	for _, x := range s.indexes {
+		x.remove(n)
+	}
+}
+
+//AddIndex indexes the nodes of the graph by the key the indexer returns, replacing any index of the same name
+func (s *IndexedGraphSearch) AddIndex(name string, by NodeIndexer) {
+	if by == nil {
+		return
+	}
+
+	x := &nodeIndex{
+		by:    by,
+		keys:  make(map[interface{}][]Nodes),
+		nodes: make(map[Nodes]interface{}),
+	}
+
+	s.ro.Lock()
+	defer s.ro.Unlock()
+
+	if s.graph != nil {
+		s.graph.nodeSet().EachNode(x.add)
+	}
+
+	s.indexes[name] = x
+}
+
+//RemoveIndex drops the index of this name
+func (s *IndexedGraphSearch) RemoveIndex(name string) {
+	s.ro.Lock()
+	delete(s.indexes, name)
+	s.ro.Unlock()
+}
+
+//Reindex updates the keys of the node in every index, it should be called when a change to the node alters its keys
+func (s *IndexedGraphSearch) Reindex(n Nodes) {
+	s.ro.Lock()
+	defer s.ro.Unlock()
+
+	for _, x := range s.indexes {
+		if _, ok := x.nodes[n]; ok {
+			x.remove(n)
+			x.add(n)
+		}
+	}
+}
+
+//Lookup returns the nodes whose key in the named index equals the key, in the order they were indexed
+func (s *IndexedGraphSearch) Lookup(name string, key interface{}) ([]Nodes, error) {
+	s.ro.Lock()
+	defer s.ro.Unlock()
+
+	x, ok := s.indexes[name]
+	if !ok {
+		return nil, ErrNoIndex
+	}
+
+	k, ok := indexKey(key)
+	if !ok || len(x.keys[k]) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return append([]Nodes(nil), x.keys[k]...), nil
+}
+
+//LookupOne returns the first node whose key in the named index equals the key
+func (s *IndexedGraphSearch) LookupOne(name string, key interface{}) (Nodes, error) {
+	nodes, err := s.Lookup(name, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes[0], nil
+}
+
+//Range returns the nodes whose key in the named index lies between min and max inclusive, ordered by key
+func (s *IndexedGraphSearch) Range(name string, min, max interface{}) ([]Nodes, error) {
+	s.ro.Lock()
+	defer s.ro.Unlock()
+
+	x, ok := s.indexes[name]
+	if !ok {
+		return nil, ErrNoIndex
+	}
+
+	lo, lok := indexKey(min)
+	hi, hok := indexKey(max)
+
+	every := min == nil && max == nil
+	rank := keyRank(lo)
+
+	if !lok {
+		rank = keyRank(hi)
+	}
+
+	if !every && (rank < 0 || (lok && hok && keyRank(lo) != keyRank(hi))) {
+		return nil, ErrNotFound
+	}
+
+	keys := x.ordered()
+
+	start := sort.Search(len(keys), func(i int) bool {
+		if lok {
+			return !keyLess(keys[i], lo)
+		}
+		return every || keyRank(keys[i]) >= rank
+	})
+
+	var res []Nodes
+
+	for _, k := range keys[start:] {
+		if !every && keyRank(k) != rank {
+			break
+		}
+
+		if hok && keyLess(hi, k) {
+			break
+		}
+
+		res = append(res, x.keys[k]...)
+	}
+
+	if res == nil {
+		return nil, ErrNotFound
+	}
+
+	return res, nil
+}
@@ -0,0 +1,226 @@
+package ds
+
+import (
+	"strings"
+	"testing"
+)
+
+type indexedPerson struct {
+	Name string
+	Age  int
+}
+
+func indexedNames(nodes []Nodes) string {
+	var names []string
+	for _, n := range nodes {
+		names = append(names, n.Value().(indexedPerson).Name)
+	}
+	return strings.Join(names, " ")
+}
+
+func TestIndexedLookup(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(
+		indexedPerson{"alice", 31},
+		indexedPerson{"carol", 31},
+		indexedPerson{"dave", 40},
+	)
+
+	search := NewIndexedGraphSearch(gs)
+	search.AddIndex("age", func(n Nodes) interface{} {
+		return n.Value().(indexedPerson).Age
+	})
+	search.AddIndex("name", func(n Nodes) interface{} {
+		return n.Value().(indexedPerson).Name
+	})
+
+	var _ GraphSearcher = search
+
+	nodes, err := search.Lookup("age", int64(31))
+	if err != nil {
+		t.Fatalf("Lookup failed: %s", err)
+	}
+
+	if got := indexedNames(nodes); got != "alice carol" {
+		t.Fatalf("Expected alice carol, got %s", got)
+	}
+
+	n, err := search.LookupOne("name", "dave")
+	if err != nil || n.Value().(indexedPerson).Age != 40 {
+		t.Fatalf("LookupOne failed: %v %v", n, err)
+	}
+
+	if _, err := search.Lookup("age", 99); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := search.Lookup("height", 1); err != ErrNoIndex {
+		t.Fatalf("Expected ErrNoIndex, got %v", err)
+	}
+}
+
+func TestIndexedRange(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(
+		indexedPerson{"alice", 31},
+		indexedPerson{"bob", 25},
+		indexedPerson{"carol", 31},
+		indexedPerson{"dave", 40},
+	)
+
+	search := NewIndexedGraphSearch(gs)
+	search.AddIndex("age", func(n Nodes) interface{} {
+		return n.Value().(indexedPerson).Age
+	})
+	search.AddIndex("name", func(n Nodes) interface{} {
+		return n.Value().(indexedPerson).Name
+	})
+
+	cases := []struct {
+		index    string
+		min, max interface{}
+		expect   string
+	}{
+		{"age", 25, 31, "bob alice carol"},
+		{"age", 30.5, nil, "alice carol dave"},
+		{"age", nil, 30, "bob"},
+		{"age", nil, nil, "bob alice carol dave"},
+		{"name", "b", "d", "bob carol"},
+		{"name", "carol", nil, "carol dave"},
+	}
+
+	for _, c := range cases {
+		nodes, err := search.Range(c.index, c.min, c.max)
+		if err != nil {
+			t.Fatalf("Range %s [%v, %v] failed: %s", c.index, c.min, c.max, err)
+		}
+
+		if got := indexedNames(nodes); got != c.expect {
+			t.Fatalf("Range %s [%v, %v]: expected %q, got %q", c.index, c.min, c.max, c.expect, got)
+		}
+	}
+
+	if _, err := search.Range("age", 1, "z"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for mixed bounds, got %v", err)
+	}
+
+	if _, err := search.Range("age", 41, nil); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for an empty range, got %v", err)
+	}
+}
+
+func TestIndexedFollowsGraph(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(
+		indexedPerson{"alice", 31},
+		indexedPerson{"bob", 25},
+		indexedPerson{"carol", 31},
+	)
+
+	search := NewIndexedGraphSearch(gs)
+	search.AddIndex("age", func(n Nodes) interface{} {
+		return n.Value().(indexedPerson).Age
+	})
+	search.AddIndex("name", func(n Nodes) interface{} {
+		return n.Value().(indexedPerson).Name
+	})
+
+	gs.Add(indexedPerson{"erin", 31})
+	gs.AddNode(NewGraphNode(indexedPerson{"frank", 50}, nil))
+
+	nodes, err := search.Lookup("age", 31)
+	if err != nil || indexedNames(nodes) != "alice carol erin" {
+		t.Fatalf("Added node missing from index: %s %v", indexedNames(nodes), err)
+	}
+
+	if _, err := search.LookupOne("name", "frank"); err != nil {
+		t.Fatalf("AddNode not indexed: %s", err)
+	}
+
+	gs.Remove(indexedPerson{"alice", 31})
+
+	nodes, err = search.Range("age", 30, 35)
+	if err != nil || indexedNames(nodes) != "carol erin" {
+		t.Fatalf("Removed node still indexed: %s %v", indexedNames(nodes), err)
+	}
+
+	bob, _ := search.LookupOne("name", "bob")
+	bob.(*Node).ChangeValue(indexedPerson{"bob", 60})
+	search.Reindex(bob)
+
+	nodes, err = search.Range("age", 55, nil)
+	if err != nil || indexedNames(nodes) != "bob" {
+		t.Fatalf("Reindex failed: %s %v", indexedNames(nodes), err)
+	}
+
+	found, err := search.FindAll(func(n Nodes) bool {
+		return n.Value().(indexedPerson).Age > 45
+	})
+	if err != nil || len(found) != 2 {
+		t.Fatalf("FindAll scan failed: %v %v", found, err)
+	}
+}
+
+func TestIndexedDuplicates(t *testing.T) {
+	gs := NewGraph()
+
+	search := NewIndexedGraphSearch(gs)
+	search.AddIndex("v", func(n Nodes) interface{} {
+		return n.Value()
+	})
+
+	gs.Add(1)
+	gs.Add(1)
+	gs.AddNode(NewGraphNode(1, nil))
+
+	if nodes, err := search.Lookup("v", 1); err != nil || len(nodes) != 1 {
+		t.Fatalf("Expected a single indexed node got %v %v", nodes, err)
+	}
+
+	gs.Remove(1)
+
+	if _, err := search.Lookup("v", 1); err != ErrNotFound {
+		t.Fatalf("Expected the removed node to leave the index got %v", err)
+	}
+}
+
+func TestIndexedRangeOpen(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("b", 2, "a", 1.5, true)
+
+	search := NewIndexedGraphSearch(gs)
+	search.AddIndex("v", func(n Nodes) interface{} {
+		return n.Value()
+	})
+
+	cases := []struct {
+		min, max interface{}
+		expect   []interface{}
+	}{
+		{nil, nil, []interface{}{1.5, 2, "a", "b"}},
+		{nil, 1.5, []interface{}{1.5}},
+		{"a", nil, []interface{}{"a", "b"}},
+		{nil, "a", []interface{}{"a"}},
+	}
+
+	for _, c := range cases {
+		nodes, err := search.Range("v", c.min, c.max)
+		if err != nil {
+			t.Fatalf("Range [%v, %v] failed: %s", c.min, c.max, err)
+		}
+
+		if len(nodes) != len(c.expect) {
+			t.Fatalf("Range [%v, %v]: expected %v got %d nodes", c.min, c.max, c.expect, len(nodes))
+		}
+
+		for i, n := range nodes {
+			if n.Value() != c.expect[i] {
+				t.Fatalf("Range [%v, %v]: expected %v at %d got %v", c.min, c.max, c.expect[i], i, n.Value())
+			}
+		}
+	}
+
+	if _, err := search.Range("v", true, nil); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound for a bound without an order got %v", err)
+	}
+}
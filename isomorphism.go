@@ -0,0 +1,430 @@
+package ds
+
+//MatchMode defines the kind of correspondence the graph matcher searches for
+type MatchMode string
+
+const (
+	//IsomorphismMatch requires both graphs to share the exact same structure
+	IsomorphismMatch MatchMode = "isomorphism"
+	//InducedMatch requires the pattern to match an induced subgraph of the target i.e edges between matched target nodes must also exist in the pattern
+	InducedMatch MatchMode = "induced-subgraph"
+	//MonomorphismMatch requires every pattern edge to exist in the target but allows extra edges between matched target nodes
+	MonomorphismMatch MatchMode = "monomorphism"
+)
+
+//NodeMatcher decides if a pattern node can be mapped unto a target node
+type NodeMatcher func(pattern, target Nodes) bool
+
+//SocketMatcher decides if a pattern socket can be mapped unto a target socket
+type SocketMatcher func(pattern, target *Socket) bool
+
+//Mapping maps the nodes of a pattern graph unto the nodes of a target graph
+type Mapping map[Nodes]Nodes
+
+//MatchNodeValues provides a NodeMatcher which compares the values of both nodes
+func MatchNodeValues(pattern, target Nodes) bool {
+	return pattern.Equals(target.Value())
+}
+
+//MatchSocketAttrs provides a SocketMatcher which requires the pattern socket attributes to exist on the target socket
+func MatchSocketAttrs(pattern, target *Socket) bool {
+	matched := true
+
+	pattern.Attrs.Each(func(attr string, _ int, stop func()) {
+		if !target.Attrs.Has(attr) {
+			matched = false
+			stop()
+		}
+	})
+
+	return matched
+}
+
+//MatchSocketWeights provides a SocketMatcher which compares the weights of both sockets
+func MatchSocketWeights(pattern, target *Socket) bool {
+	return pattern.Weight == target.Weight
+}
+
+//Isomorphic returns the first mapping of g1 unto g2 if both graphs are isomorphic
+func Isomorphic(g1, g2 Graphs, nm NodeMatcher, sm SocketMatcher) (Mapping, bool) {
+	var found Mapping
+
+	VF2(g1, g2, IsomorphismMatch, nm, sm, func(m Mapping, stop func()) {
+		found = m
+		stop()
+	})
+
+	return found, found != nil
+}
+
+//SubgraphMatches returns all occurrences of the pattern graph within the target graph
+func SubgraphMatches(pattern, target Graphs, mode MatchMode, nm NodeMatcher, sm SocketMatcher) []Mapping {
+	var found []Mapping
+
+	VF2(pattern, target, mode, nm, sm, func(m Mapping, _ func()) {
+		found = append(found, m)
+	})
+
+	return found
+}
+
+//VF2 runs the VF2 matching algorithm of the pattern graph against the target graph, calling fx with every mapping found until the supplied stop function is called
+func VF2(pattern, target Graphs, mode MatchMode, nm NodeMatcher, sm SocketMatcher, fx func(Mapping, func())) {
+	if fx == nil {
+		return
+	}
+
+	g1 := newVF2Graph(pattern)
+	g2 := newVF2Graph(target)
+
+	if len(g1.nodes) == 0 || len(g1.nodes) > len(g2.nodes) {
+		return
+	}
+
+	if mode == IsomorphismMatch {
+		if len(g1.nodes) != len(g2.nodes) || len(g1.edges) != len(g2.edges) {
+			return
+		}
+	}
+
+	state := newVF2State(g1, g2, mode, nm, sm)
+	state.match(fx)
+}
+
+//vf2Graph provides an index based view of a graph used by the matcher
+type vf2Graph struct {
+	nodes []Nodes
+	index map[Nodes]int
+	out   [][]int
+	in    [][]int
+	edges map[[2]int]*Socket
+}
+
+func newVF2Graph(g Graphs) *vf2Graph {
+	nodes := g.nodeSet().AllNodes()
+
+	vg := &vf2Graph{
+		nodes: nodes,
+		index: make(map[Nodes]int, len(nodes)),
+		out:   make([][]int, len(nodes)),
+		in:    make([][]int, len(nodes)),
+		edges: make(map[[2]int]*Socket),
+	}
+
+	for i, n := range nodes {
+		vg.index[n] = i
+	}
+
+	for i, n := range nodes {
+		for _, sock := range OutSockets(n) {
+			j, ok := vg.index[sock.To]
+			if !ok {
+				continue
+			}
+
+			key := [2]int{i, j}
+			if _, dup := vg.edges[key]; dup {
+				continue
+			}
+
+			vg.edges[key] = sock
+			vg.out[i] = append(vg.out[i], j)
+			vg.in[j] = append(vg.in[j], i)
+		}
+	}
+
+	return vg
+}
+
+func (vg *vf2Graph) edge(from, to int) *Socket {
+	return vg.edges[[2]int{from, to}]
+}
+
+//vf2Counts holds the look-ahead counts of a candidate pair
+type vf2Counts struct {
+	in, out, fresh int
+}
+
+//vf2State provides the search state of the VF2 matcher
+type vf2State struct {
+	g1, g2     *vf2Graph
+	mode       MatchMode
+	nm         NodeMatcher
+	sm         SocketMatcher
+	core1      []int
+	core2      []int
+	in1, out1  []int
+	in2, out2  []int
+	depth      int
+	terminated bool
+}
+
+func newVF2State(g1, g2 *vf2Graph, mode MatchMode, nm NodeMatcher, sm SocketMatcher) *vf2State {
+	s := &vf2State{
+		g1:    g1,
+		g2:    g2,
+		mode:  mode,
+		nm:    nm,
+		sm:    sm,
+		core1: make([]int, len(g1.nodes)),
+		core2: make([]int, len(g2.nodes)),
+		in1:   make([]int, len(g1.nodes)),
+		out1:  make([]int, len(g1.nodes)),
+		in2:   make([]int, len(g2.nodes)),
+		out2:  make([]int, len(g2.nodes)),
+	}
+
+	for i := range s.core1 {
+		s.core1[i] = -1
+	}
+
+	for i := range s.core2 {
+		s.core2[i] = -1
+	}
+
+	return s
+}
+
+func (s *vf2State) match(fx func(Mapping, func())) {
+	if s.terminated {
+		return
+	}
+
+	if s.depth == len(s.g1.nodes) {
+		mapping := make(Mapping, len(s.core1))
+		for i, j := range s.core1 {
+			mapping[s.g1.nodes[i]] = s.g2.nodes[j]
+		}
+		fx(mapping, func() { s.terminated = true })
+		return
+	}
+
+	n, candidates := s.candidates()
+
+	for _, m := range candidates {
+		if s.terminated {
+			return
+		}
+
+		if !s.feasible(n, m) {
+			continue
+		}
+
+		s.push(n, m)
+		s.match(fx)
+		s.pop(n, m)
+	}
+}
+
+//candidates returns the next pattern node and the target nodes it may be paired with
+func (s *vf2State) candidates() (int, []int) {
+	pick := func(core, set []int) int {
+		for i, c := range core {
+			if c == -1 && set[i] > 0 {
+				return i
+			}
+		}
+		return -1
+	}
+
+	collect := func(set []int) []int {
+		var ms []int
+		for j, c := range s.core2 {
+			if c == -1 && (set == nil || set[j] > 0) {
+				ms = append(ms, j)
+			}
+		}
+		return ms
+	}
+
+	if n := pick(s.core1, s.out1); n != -1 {
+		return n, collect(s.out2)
+	}
+
+	if n := pick(s.core1, s.in1); n != -1 {
+		return n, collect(s.in2)
+	}
+
+	for i, c := range s.core1 {
+		if c == -1 {
+			return i, collect(nil)
+		}
+	}
+
+	return -1, nil
+}
+
+func (s *vf2State) sockets(p, t *Socket) bool {
+	if s.sm == nil {
+		return true
+	}
+	return s.sm(p, t)
+}
+
+//feasible checks the syntactic and semantic rules for adding the pair (n,m)
+func (s *vf2State) feasible(n, m int) bool {
+	if s.nm != nil && !s.nm(s.g1.nodes[n], s.g2.nodes[m]) {
+		return false
+	}
+
+	loop1, loop2 := s.g1.edge(n, n), s.g2.edge(m, m)
+
+	if loop1 != nil && (loop2 == nil || !s.sockets(loop1, loop2)) {
+		return false
+	}
+
+	if loop1 == nil && loop2 != nil && s.mode != MonomorphismMatch {
+		return false
+	}
+
+	var c1, c2 vf2Counts
+
+	for _, j := range s.g1.out[n] {
+		if j == n {
+			continue
+		}
+
+		if mj := s.core1[j]; mj != -1 {
+			e2 := s.g2.edge(m, mj)
+			if e2 == nil || !s.sockets(s.g1.edge(n, j), e2) {
+				return false
+			}
+			continue
+		}
+
+		s.count(&c1, s.in1[j], s.out1[j])
+	}
+
+	for _, j := range s.g1.in[n] {
+		if j == n {
+			continue
+		}
+
+		if mj := s.core1[j]; mj != -1 {
+			e2 := s.g2.edge(mj, m)
+			if e2 == nil || !s.sockets(s.g1.edge(j, n), e2) {
+				return false
+			}
+			continue
+		}
+
+		s.count(&c1, s.in1[j], s.out1[j])
+	}
+
+	for _, j := range s.g2.out[m] {
+		if j == m {
+			continue
+		}
+
+		if nj := s.core2[j]; nj != -1 {
+			if s.mode != MonomorphismMatch && s.g1.edge(n, nj) == nil {
+				return false
+			}
+			continue
+		}
+
+		s.count(&c2, s.in2[j], s.out2[j])
+	}
+
+	for _, j := range s.g2.in[m] {
+		if j == m {
+			continue
+		}
+
+		if nj := s.core2[j]; nj != -1 {
+			if s.mode != MonomorphismMatch && s.g1.edge(nj, n) == nil {
+				return false
+			}
+			continue
+		}
+
+		s.count(&c2, s.in2[j], s.out2[j])
+	}
+
+	switch s.mode {
+	case IsomorphismMatch:
+		return c1 == c2
+	case InducedMatch:
+		return c1.in <= c2.in && c1.out <= c2.out && c1.fresh <= c2.fresh
+	default:
+		return c1.in <= c2.in && c1.out <= c2.out
+	}
+}
+
+func (s *vf2State) count(c *vf2Counts, in, out int) {
+	if in > 0 {
+		c.in++
+	}
+	if out > 0 {
+		c.out++
+	}
+	if in == 0 && out == 0 {
+		c.fresh++
+	}
+}
+
+//push adds the pair (n,m) to the state and grows the terminal sets
+func (s *vf2State) push(n, m int) {
+	s.depth++
+	d := s.depth
+
+	s.core1[n] = m
+	s.core2[m] = n
+
+	enter := func(set []int, i int) {
+		if set[i] == 0 {
+			set[i] = d
+		}
+	}
+
+	enter(s.in1, n)
+	enter(s.out1, n)
+	enter(s.in2, m)
+	enter(s.out2, m)
+
+	for _, j := range s.g1.in[n] {
+		enter(s.in1, j)
+	}
+	for _, j := range s.g1.out[n] {
+		enter(s.out1, j)
+	}
+	for _, j := range s.g2.in[m] {
+		enter(s.in2, j)
+	}
+	for _, j := range s.g2.out[m] {
+		enter(s.out2, j)
+	}
+}
+
+//pop removes the pair (n,m) from the state and restores the terminal sets
+func (s *vf2State) pop(n, m int) {
+	d := s.depth
+
+	leave := func(set []int, i int) {
+		if set[i] == d {
+			set[i] = 0
+		}
+	}
+
+	leave(s.in1, n)
+	leave(s.out1, n)
+	leave(s.in2, m)
+	leave(s.out2, m)
+
+	for _, j := range s.g1.in[n] {
+		leave(s.in1, j)
+	}
+	for _, j := range s.g1.out[n] {
+		leave(s.out1, j)
+	}
+	for _, j := range s.g2.in[m] {
+		leave(s.in2, j)
+	}
+	for _, j := range s.g2.out[m] {
+		leave(s.out2, j)
+	}
+
+	s.core1[n] = -1
+	s.core2[m] = -1
+	s.depth--
+}
@@ -0,0 +1,101 @@
+package ds
+
+import "testing"
+
+func TestIsomorphic(t *testing.T) {
+	g1 := NewGraph()
+	g1.Add(1, 2, 3, 4)
+	g1.Bind(1, 2, 0)
+	g1.Bind(2, 3, 0)
+	g1.Bind(3, 4, 0)
+	g1.Bind(4, 1, 0)
+
+	g2 := NewGraph()
+	g2.Add("a", "b", "c", "d")
+	g2.Bind("c", "a", 0)
+	g2.Bind("a", "d", 0)
+	g2.Bind("d", "b", 0)
+	g2.Bind("b", "c", 0)
+
+	mapping, ok := Isomorphic(g1, g2, nil, nil)
+
+	if !ok {
+		t.Fatal("Expected both cycles to be isomorphic")
+	}
+
+	for from, to := range mapping {
+		next := g1.Get(from.Value().(int)%4 + 1)
+		if !to.HasEdge(mapping[next]) {
+			t.Fatalf("Mapping of %s does not preserve its edge", from)
+		}
+	}
+
+	g2.Bind("a", "c", 0)
+
+	if _, ok := Isomorphic(g1, g2, nil, nil); ok {
+		t.Fatal("Expected graphs with different edges not to be isomorphic")
+	}
+}
+
+func TestSubgraphMatches(t *testing.T) {
+	target := NewGraph()
+	target.Add("api", "db", "cache", "queue")
+
+	so, _ := target.Bind("api", "db", 0)
+	so.Attrs.Add("depends")
+
+	so, _ = target.Bind("api", "cache", 0)
+	so.Attrs.Add("depends")
+
+	so, _ = target.Bind("cache", "db", 0)
+	so.Attrs.Add("reads")
+
+	target.Bind("queue", "db", 0)
+
+	pattern := NewGraph()
+	pattern.Add("x", "y")
+
+	so, _ = pattern.Bind("x", "y", 0)
+	so.Attrs.Add("depends")
+
+	found := SubgraphMatches(pattern, target, MonomorphismMatch, nil, MatchSocketAttrs)
+
+	if len(found) != 2 {
+		t.Fatalf("Expected 2 matches of the pattern got %d", len(found))
+	}
+
+	for _, m := range found {
+		if m[pattern.Get("x")].Value() != "api" {
+			t.Fatalf("Expected pattern node 'x' to match 'api' got %s", m[pattern.Get("x")])
+		}
+	}
+
+	found = SubgraphMatches(pattern, target, MonomorphismMatch, func(p, t Nodes) bool {
+		return p.Value() != "y" || t.Value() == "db"
+	}, MatchSocketAttrs)
+
+	if len(found) != 1 {
+		t.Fatalf("Expected 1 match with node predicate got %d", len(found))
+	}
+}
+
+func TestInducedSubgraphMatches(t *testing.T) {
+	target := NewGraph()
+	target.Add(1, 2, 3)
+	target.Bind(1, 2, 0)
+	target.Bind(2, 3, 0)
+	target.Bind(1, 3, 0)
+
+	pattern := NewGraph()
+	pattern.Add("a", "b", "c")
+	pattern.Bind("a", "b", 0)
+	pattern.Bind("b", "c", 0)
+
+	if found := SubgraphMatches(pattern, target, MonomorphismMatch, nil, nil); len(found) != 1 {
+		t.Fatalf("Expected 1 monomorphism got %d", len(found))
+	}
+
+	if found := SubgraphMatches(pattern, target, InducedMatch, nil, nil); len(found) != 0 {
+		t.Fatalf("Expected no induced match got %d", len(found))
+	}
+}
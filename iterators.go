@@ -1,6 +1,7 @@
 package ds
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -13,6 +14,18 @@ var (
 	// ErrNotFound is returned when no node is found matching criteria
 	ErrNotFound = errors.New("Node not Found")
 
+	// ErrVisitLimit is returned when a transversal has used up its MaxVisits budget
+	ErrVisitLimit = errors.New("Transversal visit limit reached")
+
+	// ErrNoCheckpoint is returned when a transversal order can not be checkpointed
+	ErrNoCheckpoint = errors.New("Transversal order does not support checkpoints")
+
+	// ErrStaleCheckpoint is returned when a checkpoint frontier walked more sockets than its nodes now hold
+	ErrStaleCheckpoint = errors.New("Checkpoint does not match the graph")
+
+	// ErrNoIndex is returned when a search names an index that was never added
+	ErrNoIndex = errors.New("Index not found")
+
 	defaultVisit = func(n Nodes, visited bool) bool {
 		if visited {
 			return false
@@ -44,6 +57,24 @@ const (
 	BFPreOrder TransversalOrder = "breadth-first-preorder"
 	//BFPostOrder represents breadth first order of starting with the node children then go to the node
 	BFPostOrder TransversalOrder = "breadth-first-postorder"
+	//BestFirst represents an order that expands the frontier node with the lowest Priority first
+	BestFirst TransversalOrder = "best-first"
+	//Weighted represents a uniform-cost order that expands the frontier node with the lowest total socket weight from the start first
+	Weighted TransversalOrder = "weighted"
+	//IterativeDeepening represents repeated depth-limited depth first passes with a growing limit, each node is visited once at its shallowest depth
+	IterativeDeepening TransversalOrder = "iterative-deepening"
+)
+
+//Direction provides the direction sockets are followed in by a TranversalDirective
+type Direction string
+
+const (
+	//Out follows sockets from their From node to their To node, this is the default
+	Out Direction = "out"
+	//In follows sockets from their To node back to their From node
+	In Direction = "in"
+	//Both follows sockets regardless of their direction
+	Both Direction = "both"
 )
 
 //VisitCaller provides a type for visit checks
@@ -52,6 +83,9 @@ type VisitCaller func(Nodes, bool) bool
 //NodeOp provide a type for running on graph iterators
 type NodeOp func(Nodes, *Socket) error
 
+//Priority provides a function type ranking a frontier node reached by the socket at the depth, lower values are expanded first
+type Priority func(Nodes, *Socket, int) float64
+
 //NodeEval provides a evalutor format type
 type NodeEval func(Nodes, *Socket, int) bool
 
@@ -62,6 +96,12 @@ type TransversalDirective struct {
 	Revisits  VisitCaller
 	Heuristic NodeOp
 	AllNodes  bool
+	//Direction sets which sockets of a node are followed, an empty Direction means Out
+	Direction Direction
+	//MaxVisits caps the number of nodes a transversal may visit, zero means no limit
+	MaxVisits int
+	//Priority ranks frontier nodes for the BestFirst order, for the Weighted order it is added to the cost as an estimate of the remaining distance
+	Priority Priority
 }
 
 //MakeTransversalDirective creates a transversal directive
@@ -96,6 +136,23 @@ func DFPreOrderDirective(v VisitCaller, hx NodeOp) *TransversalDirective {
 	return MakeTransversalDirective(-1, DFPreOrder, v, hx)
 }
 
+//BestFirstDirective provides a copy of a best-first rule using the priority
+func BestFirstDirective(p Priority, v VisitCaller, hx NodeOp) *TransversalDirective {
+	dir := MakeTransversalDirective(-1, BestFirst, v, hx)
+	dir.Priority = p
+	return dir
+}
+
+//IDDFSDirective provides a copy of an iterative deepening rule bounded by the depth, a depth of -1 deepens until no new nodes are found
+func IDDFSDirective(depth int, v VisitCaller, hx NodeOp) *TransversalDirective {
+	return MakeTransversalDirective(depth, IterativeDeepening, v, hx)
+}
+
+//WeightedDirective provides a copy of a uniform-cost rule
+func WeightedDirective(v VisitCaller, hx NodeOp) *TransversalDirective {
+	return MakeTransversalDirective(-1, Weighted, v, hx)
+}
+
 //NodeMaps represent the node map used by a iterator
 type NodeMaps map[Nodes]bool
 
@@ -118,174 +175,215 @@ func DepthFirstPreOrder(directive *TransversalDirective) (t *Transversor) {
 	t = MakeTransversor(directive)
 
 	var cache = NewCache()
-	unlocked := true
 
 	t.reset = func() {
 		cache.Reset()
 	}
 
-	t.next = func() error {
-		if cache.Length() <= 0 && t.started > 0 {
-			t.current = nil
-			return sequence.ErrBADINDEX
-		}
+	t.save = func(cp *Checkpoint, key NodeKeyer) {
+		cp.Frontier = saveFrames(cache, key)
+	}
 
-		if t.started <= 0 {
-			atomic.StoreInt64(&t.started, 1)
-			unlocked = false
-			cache.AddCache(t.from)
+	t.load = func(cp *Checkpoint, resolve NodeResolver) error {
+		frames, err := t.loadFrames(cp.Frontier, resolve)
+		if err != nil {
+			return err
 		}
 
-		if t.directive.Depth != -1 {
-			if t.directive.Depth <= t.WalkDepth() {
-				return ErrBadIndex
+		cache = frames
+		return nil
+	}
+
+	t.next = func() error {
+		for {
+			if err := t.interrupted(); err != nil {
+				return err
 			}
-		}
 
-		cur, err := cache.LastCache()
+			if cache.Length() <= 0 {
+				root := t.nextRoot()
 
-		if err != nil {
-			t.current = nil
-			return err
-		}
+				if root == nil {
+					t.current = nil
+					return sequence.ErrBADINDEX
+				}
 
-		node, itr := cur.Node, cur.Itr
+				atomic.StoreInt64(&t.started, 1)
+				atomic.StoreInt64(&t.walkdepth, 0)
+				cache.addNodeCache(t.nodeCache(root))
+			}
 
-		if t.directive.Heuristic(node, t.keys[node]) != nil {
-			cache.Uncache()
-			return t.Next()
-		}
+			if t.directive.Depth != -1 {
+				if t.directive.Depth <= t.WalkDepth() {
+					return ErrBadIndex
+				}
+			}
 
-		if t.directive.Revisits(node, t.visited.Valid(node)) {
+			cur, err := cache.LastCache()
 
-			// if t.directive.Heuristic(node, t.keys[node]) != nil {
-			// 	cache.Uncache()
-			// 	return t.Next()
-			// }
+			if err != nil {
+				t.current = nil
+				return err
+			}
 
-			t.visited.Add(node)
-			t.current = node
-			return nil
-		}
+			node, itr := cur.Node, cur.Itr
+			parent, soc := discoveredBy(cache)
 
-		// if t.directive.Heuristic(node, t.keys[node]) != nil {
-		// 	cache.Uncache()
-		// 	return t.Next()
-		// }
+			if t.directive.Heuristic(node, soc) != nil {
+				cache.Uncache()
+				continue
+			}
 
-		err = itr.Next()
+			if t.directive.Revisits(node, t.visited.Valid(node)) {
+				if soc != nil {
+					t.link(node, parent, soc)
+				}
 
-		if err != nil {
-			atomic.AddInt64(&t.walkdepth, -1)
-			cache.Uncache()
-			return t.Next()
-		}
+				t.visited.Add(node)
+				t.current = node
+				return nil
+			}
 
-		cursoc, ok := itr.Value().(*Socket)
+			if err := itr.Next(); err != nil {
+				atomic.AddInt64(&t.walkdepth, -1)
+				cache.Uncache()
+				continue
+			}
 
-		if !ok {
-			t.current = nil
-			return ErrBadEdgeType
-		}
+			cursoc, ok := itr.Value().(*Socket)
 
-		node = cursoc.To
+			if !ok {
+				t.current = nil
+				return ErrBadEdgeType
+			}
 
-		if !t.directive.Revisits(node, t.visited.Valid(node)) {
-			return t.Next()
-		}
+			node = peer(cursoc, node)
 
-		t.keys[node] = cursoc
-		cache.AddCache(node)
+			if !t.directive.Revisits(node, t.visited.Valid(node)) {
+				continue
+			}
 
-		atomic.AddInt64(&t.walkdepth, 1)
+			cache.addNodeCache(t.nodeCache(node))
 
-		return t.Next()
+			atomic.AddInt64(&t.walkdepth, 1)
+		}
 	}
 
 	return
 }
 
+//discoveredBy returns the node below the top of a depth first cache and the socket it reached the top node by, nil for a root
+func discoveredBy(cache NodeCaches) (Nodes, *Socket) {
+	if len(cache) < 2 {
+		return nil, nil
+	}
+
+	below := cache[len(cache)-2]
+	soc, _ := below.Itr.Value().(*Socket)
+
+	return below.Node, soc
+}
+
 //DepthFirstPostOrder returns a depth first search provider
 func DepthFirstPostOrder(directive *TransversalDirective) (t *Transversor) {
 	t = MakeTransversor(directive)
 
 	var cache = NewCache()
-	var curnode *NodeCache
-	var err error
 
 	t.reset = func() {
 		cache.Reset()
 	}
 
-	t.next = func() error {
-		if cache.Length() <= 0 && t.started > 0 {
-			return ErrBadIndex
-		}
+	t.save = func(cp *Checkpoint, key NodeKeyer) {
+		cp.Frontier = saveFrames(cache, key)
+	}
 
-		if t.started <= 0 {
-			atomic.StoreInt64(&t.started, 1)
-			cache.AddCache(t.from)
-			t.visited.Add(t.from)
+	t.load = func(cp *Checkpoint, resolve NodeResolver) error {
+		frames, err := t.loadFrames(cp.Frontier, resolve)
+		if err != nil {
+			return err
 		}
 
-		if t.directive.Depth != -1 {
-			if t.directive.Depth <= t.WalkDepth() {
-				return ErrBadIndex
+		cache = frames
+		return nil
+	}
+
+	t.next = func() error {
+		for {
+			if err := t.interrupted(); err != nil {
+				return err
 			}
-		}
 
-		curnode, err = cache.LastCache()
+			if cache.Length() <= 0 {
+				root := t.nextRoot()
 
-		if err != nil {
-			t.current = nil
-			return ErrBadIndex
-		}
+				if root == nil {
+					return ErrBadIndex
+				}
 
-		cur := curnode.Itr
-		node := curnode.Node
+				atomic.StoreInt64(&t.started, 1)
+				atomic.StoreInt64(&t.walkdepth, 0)
+				cache.addNodeCache(t.nodeCache(root))
+				t.visited.Add(root)
+			}
 
-		if err := cur.Next(); err != nil {
-			if t.directive.Revisits(node, t.visited.Valid(node)) {
-				cache.AddCache(node)
-				if t.walkdepth > 0 {
-					atomic.AddInt64(&t.walkdepth, -1)
+			if t.directive.Depth != -1 {
+				if t.directive.Depth <= t.WalkDepth() {
+					return ErrBadIndex
 				}
-				t.visited.Add(node)
-				return t.Next()
 			}
 
-			if t.walkdepth > 0 {
-				atomic.AddInt64(&t.walkdepth, -1)
+			curnode, err := cache.LastCache()
+
+			if err != nil {
+				t.current = nil
+				return ErrBadIndex
 			}
 
-			cache.Uncache()
-			t.current = node
-			return nil
-		}
+			cur := curnode.Itr
+			node := curnode.Node
+
+			if err := cur.Next(); err != nil {
+				if t.directive.Revisits(node, t.visited.Valid(node)) {
+					cache.addNodeCache(t.nodeCache(node))
+					if t.walkdepth > 0 {
+						atomic.AddInt64(&t.walkdepth, -1)
+					}
+					t.visited.Add(node)
+					continue
+				}
 
-		curnode, ok := cur.Value().(*Socket)
+				if t.walkdepth > 0 {
+					atomic.AddInt64(&t.walkdepth, -1)
+				}
 
-		if !ok {
-			t.current = nil
-			return ErrBadEdgeType
-		}
+				cache.Uncache()
+				t.current = node
+				return nil
+			}
 
-		co := curnode.To
+			soc, ok := cur.Value().(*Socket)
 
-		atomic.AddInt64(&t.walkdepth, 1)
-		if !t.directive.Revisits(co, t.visited.Valid(co)) {
-			return t.Next()
-		}
+			if !ok {
+				t.current = nil
+				return ErrBadEdgeType
+			}
 
-		t.keys[co] = curnode
+			co := peer(soc, node)
 
-		if t.directive.Heuristic(co, t.keys[co]) != nil {
-			return t.Next()
-		}
+			atomic.AddInt64(&t.walkdepth, 1)
+			if !t.directive.Revisits(co, t.visited.Valid(co)) {
+				continue
+			}
+
+			if t.directive.Heuristic(co, soc) != nil {
+				continue
+			}
 
-		cache.AddCache(co)
-		t.visited.Add(co)
-		return t.Next()
+			t.link(co, node, soc)
+			cache.addNodeCache(t.nodeCache(co))
+			t.visited.Add(co)
+		}
 	}
 
 	return
@@ -297,78 +395,130 @@ func BreadthFirstPreOrder(directive *TransversalDirective) (t *Transversor) {
 
 	unlocked := true
 	var cache = NewCache()
+	var pending []Nodes
 
 	t.reset = func() {
 		cache.Reset()
+		pending = nil
+		unlocked = true
 	}
 
-	t.next = func() error {
-		if cache.Length() <= 0 && t.started > 0 {
-			return sequence.ErrBADINDEX
-		}
+	t.save = func(cp *Checkpoint, key NodeKeyer) {
+		cp.Frontier = saveFrames(cache, key)
+		cp.Unlocked = unlocked
 
-		if cache.Length() <= 0 {
-			atomic.StoreInt64(&t.started, 1)
-			cache.AddCache(t.from)
-			t.visited.Add(t.from)
-			t.current = t.from
-			return nil
+		for _, n := range pending {
+			cp.Pending = append(cp.Pending, key(n))
 		}
+	}
 
-		if t.directive.Depth != -1 {
-			if t.directive.Depth <= t.WalkDepth() {
-				return ErrBadIndex
-			}
-		}
-		cur, err := cache.FirstCache()
-
+	t.load = func(cp *Checkpoint, resolve NodeResolver) error {
+		frames, err := t.loadFrames(cp.Frontier, resolve)
 		if err != nil {
-			t.current = nil
 			return err
 		}
 
-		node, itr := cur.Node, cur.Itr
+		cache = frames
+		unlocked = cp.Unlocked
 
-		if t.directive.Revisits(node, t.visited.Valid(node)) {
-			cache.AddCache(node)
-			return t.Next()
+		for _, id := range cp.Pending {
+			n := resolve(id)
+			if n == nil {
+				return ErrNotFound
+			}
+			pending = append(pending, n)
 		}
 
-		if err := itr.Next(); err != nil {
-			unlocked = true
-			cache.UncacheRight()
-			return t.Next()
-		}
+		return nil
+	}
 
-		if unlocked {
-			atomic.AddInt64(&t.walkdepth, 1)
-			unlocked = false
-		}
+	t.next = func() error {
+		for {
+			if err := t.interrupted(); err != nil {
+				return err
+			}
 
-		soc, ok := itr.Value().(*Socket)
+			if len(pending) > 0 {
+				t.current = pending[0]
+				pending = pending[1:]
+				return nil
+			}
 
-		if !ok {
-			t.current = nil
-			return ErrBadEdgeType
-		}
+			if cache.Length() <= 0 {
+				roots := t.nextRoots()
 
-		no := soc.To
+				if len(roots) == 0 {
+					return sequence.ErrBADINDEX
+				}
 
-		if !t.directive.Revisits(no, t.visited.Valid(no)) {
-			return t.Next()
-		}
+				atomic.StoreInt64(&t.started, 1)
+				atomic.StoreInt64(&t.walkdepth, 0)
+				unlocked = true
 
-		t.keys[no] = soc
+				for _, root := range roots {
+					cache.addNodeCache(t.nodeCache(root))
+					t.visited.Add(root)
+				}
 
-		if t.directive.Heuristic(no, t.keys[no]) != nil {
-			// cache.Uncache()
-			return t.Next()
-		}
+				t.current = roots[0]
+				pending = roots[1:]
+				return nil
+			}
 
-		t.visited.Add(no)
-		cache.AddCache(no)
-		t.current = no
-		return nil
+			if t.directive.Depth != -1 {
+				if t.directive.Depth <= t.WalkDepth() {
+					return ErrBadIndex
+				}
+			}
+
+			cur, err := cache.FirstCache()
+
+			if err != nil {
+				t.current = nil
+				return err
+			}
+
+			node, itr := cur.Node, cur.Itr
+
+			if t.directive.Revisits(node, t.visited.Valid(node)) {
+				cache.addNodeCache(t.nodeCache(node))
+				continue
+			}
+
+			if err := itr.Next(); err != nil {
+				unlocked = true
+				cache.UncacheRight()
+				continue
+			}
+
+			if unlocked {
+				atomic.AddInt64(&t.walkdepth, 1)
+				unlocked = false
+			}
+
+			soc, ok := itr.Value().(*Socket)
+
+			if !ok {
+				t.current = nil
+				return ErrBadEdgeType
+			}
+
+			no := peer(soc, node)
+
+			if !t.directive.Revisits(no, t.visited.Valid(no)) {
+				continue
+			}
+
+			if t.directive.Heuristic(no, soc) != nil {
+				continue
+			}
+
+			t.link(no, node, soc)
+			t.visited.Add(no)
+			cache.addNodeCache(t.nodeCache(no))
+			t.current = no
+			return nil
+		}
 	}
 
 	return
@@ -379,86 +529,120 @@ func BreadthFirstPostOrder(directive *TransversalDirective) (t *Transversor) {
 	t = MakeTransversor(directive)
 
 	var cache = NewCache()
-	var curnode *NodeCache
-	var err error
 	depths := make(map[Nodes]int64)
 
 	t.reset = func() {
 		cache.Reset()
+		depths = make(map[Nodes]int64)
 	}
 
-	t.next = func() error {
-		if cache.Length() <= 0 && t.started > 0 {
-			depths = nil
-			return ErrBadIndex
+	t.save = func(cp *Checkpoint, key NodeKeyer) {
+		cp.Frontier = saveFrames(cache, key)
+		cp.Depths = make(map[string]int, len(depths))
+
+		for n, d := range depths {
+			cp.Depths[key(n)] = int(d)
 		}
+	}
 
-		if t.started <= 0 {
-			atomic.StoreInt64(&t.started, 1)
-			depths[t.from] = int64(0)
-			cache.AddCache(t.from)
+	t.load = func(cp *Checkpoint, resolve NodeResolver) error {
+		frames, err := t.loadFrames(cp.Frontier, resolve)
+		if err != nil {
+			return err
 		}
 
-		if t.directive.Depth != -1 {
-			if t.directive.Depth <= t.WalkDepth() {
-				return ErrBadIndex
+		cache = frames
+
+		for id, d := range cp.Depths {
+			n := resolve(id)
+			if n == nil {
+				return ErrNotFound
 			}
+			depths[n] = int64(d)
 		}
-		curnode, err = cache.FirstCache()
 
-		if err != nil {
-			t.current = nil
-			return ErrBadIndex
-		}
+		return nil
+	}
 
-		cur := curnode.Itr
-		node := curnode.Node
+	t.next = func() error {
+		for {
+			if err := t.interrupted(); err != nil {
+				return err
+			}
 
-		if err := cur.Next(); err != nil {
-			cache.UncacheRight()
+			if cache.Length() <= 0 {
+				roots := t.nextRoots()
 
-			if !t.directive.Revisits(node, t.visited.Valid(node)) {
-				return t.Next()
+				if len(roots) == 0 {
+					return ErrBadIndex
+				}
+
+				atomic.StoreInt64(&t.started, 1)
+
+				for _, root := range roots {
+					depths[root] = int64(0)
+					cache.addNodeCache(t.nodeCache(root))
+				}
 			}
 
-			atomic.StoreInt64(&t.walkdepth, depths[node])
-			t.visited.Add(node)
-			t.current = node
-			return nil
-		}
+			if t.directive.Depth != -1 {
+				if t.directive.Depth <= t.WalkDepth() {
+					return ErrBadIndex
+				}
+			}
 
-		curnode, ok := cur.Value().(*Socket)
+			curnode, err := cache.FirstCache()
 
-		if !ok {
-			t.current = nil
-			return ErrBadEdgeType
-		}
+			if err != nil {
+				t.current = nil
+				return ErrBadIndex
+			}
 
-		co := curnode.To
+			cur := curnode.Itr
+			node := curnode.Node
 
-		if !t.directive.Revisits(co, t.visited.Valid(co)) {
-			return t.Next()
-		}
+			if err := cur.Next(); err != nil {
+				cache.UncacheRight()
+
+				if !t.directive.Revisits(node, t.visited.Valid(node)) {
+					continue
+				}
 
-		t.keys[co] = curnode
+				atomic.StoreInt64(&t.walkdepth, depths[node])
+				t.visited.Add(node)
+				t.current = node
+				return nil
+			}
 
-		if t.directive.Heuristic(co, t.keys[co]) != nil {
-			// cache.UncacheRight()
-			// cache.Uncache()
-			return t.Next()
-		}
+			soc, ok := cur.Value().(*Socket)
+
+			if !ok {
+				t.current = nil
+				return ErrBadEdgeType
+			}
+
+			co := peer(soc, node)
+
+			if !t.directive.Revisits(co, t.visited.Valid(co)) {
+				continue
+			}
+
+			if t.directive.Heuristic(co, soc) != nil {
+				continue
+			}
+
+			t.link(co, node, soc)
+
+			if _, ok := depths[co]; !ok {
+				depths[co] = depths[node] + 1
+			}
 
-		if _, ok := depths[co]; !ok {
-			dp := depths[node]
-			depths[co] = dp + 1
 			atomic.StoreInt64(&t.walkdepth, depths[co])
+			cache.addNodeCache(t.nodeCache(co))
+			t.visited.Add(co)
+			t.current = co
+			return nil
 		}
-
-		atomic.StoreInt64(&t.walkdepth, depths[co])
-		cache.AddCache(co)
-		t.visited.Add(co)
-		t.current = co
-		return nil
 	}
 
 	return
@@ -478,9 +662,20 @@ type Transversor struct {
 	depths        map[Nodes]int64
 	started       int64
 	walkdepth     int64
+	visits        int64
 	visited       NodeMaps
+	incoming      map[Nodes][]*Socket
+	shared        map[Nodes][]*Socket
+	roots         map[Nodes]Nodes
+	sources       []Nodes
+	forest        []Nodes
+	sourced       int
+	forested      int
+	ctx           context.Context
 	next          Next
 	reset         Fx
+	save          func(*Checkpoint, NodeKeyer)
+	load          func(*Checkpoint, NodeResolver) error
 }
 
 //MakeTransversor returns a default Transversor
@@ -489,6 +684,7 @@ func MakeTransversor(dir *TransversalDirective) *Transversor {
 		directive: dir,
 		visited:   VisitMaps(),
 		keys:      make(map[Nodes]*Socket),
+		roots:     make(map[Nodes]Nodes),
 	}
 
 	return core
@@ -508,6 +704,10 @@ func CreateGraphTransversor(dir *TransversalDirective) (*Transversor, error) {
 		verso = BreadthFirstPostOrder(dir)
 	case BFPreOrder:
 		verso = BreadthFirstPreOrder(dir)
+	case BestFirst, Weighted:
+		verso = PriorityFirst(dir)
+	case IterativeDeepening:
+		verso = IterativeDeepeningOrder(dir)
 	default:
 		return nil, fmt.Errorf("Unknown Transversal Order %s", dir.Order)
 	}
@@ -535,9 +735,125 @@ func BreadthFirstPostOrderIterator(v VisitCaller, hx NodeOp) (*Transversor, erro
 	return CreateGraphTransversor(BFPostOrderDirective(v, hx))
 }
 
+//BestFirstIterator returns a best-first transverso
+func BestFirstIterator(p Priority, v VisitCaller, hx NodeOp) (*Transversor, error) {
+	return CreateGraphTransversor(BestFirstDirective(p, v, hx))
+}
+
+//WeightedIterator returns a uniform-cost transverso
+func WeightedIterator(v VisitCaller, hx NodeOp) (*Transversor, error) {
+	return CreateGraphTransversor(WeightedDirective(v, hx))
+}
+
 //Use sets the node to tranversal from
 func (t *Transversor) Use(n Nodes) {
 	t.from = n
+	t.sources = []Nodes{n}
+}
+
+//UseMany sets the nodes to tranversal from, breadth-first orders start from all of them at once while depth-first orders walk from each in turn
+func (t *Transversor) UseMany(ns ...Nodes) {
+	t.from = nil
+	t.sources = nil
+
+	for _, n := range ns {
+		if n == nil {
+			continue
+		}
+
+		if t.from == nil {
+			t.from = n
+		}
+
+		t.sources = append(t.sources, n)
+	}
+}
+
+//Root returns the start node of the tree the giving node was reached in, nil if the node has not been visited
+func (t *Transversor) Root(n Nodes) Nodes {
+	if !t.visited.Valid(n) {
+		return nil
+	}
+	return t.roots[n]
+}
+
+//rooted marks the node as the root of a new tree, returning false if it has already been reached
+func (t *Transversor) rooted(n Nodes) bool {
+	if n == nil || t.visited.Valid(n) {
+		return false
+	}
+
+	if _, ok := t.roots[n]; ok {
+		return false
+	}
+
+	t.roots[n] = n
+	return true
+}
+
+//nextRoot returns the next start node not yet reached, taken from the sources and then from the graph when AllNodes is set, nil once none are left
+func (t *Transversor) nextRoot() Nodes {
+	for t.sourced < len(t.sources) {
+		n := t.sources[t.sourced]
+		t.sourced++
+
+		if t.rooted(n) {
+			return n
+		}
+	}
+
+	if !t.directive.AllNodes {
+		return nil
+	}
+
+	if t.forest == nil {
+		t.forest = []Nodes{}
+		if gr := t.from.Graph(); gr != nil {
+			t.forest = gr.nodeSet().AllNodes()
+		}
+	}
+
+	for t.forested < len(t.forest) {
+		n := t.forest[t.forested]
+		t.forested++
+
+		if t.rooted(n) {
+			return n
+		}
+	}
+
+	return nil
+}
+
+//nextRoots returns all sources not yet reached, or the next start node from the graph once they are used up
+func (t *Transversor) nextRoots() []Nodes {
+	var roots []Nodes
+
+	for t.sourced < len(t.sources) {
+		n := t.sources[t.sourced]
+		t.sourced++
+
+		if t.rooted(n) {
+			roots = append(roots, n)
+		}
+	}
+
+	if len(roots) == 0 {
+		if n := t.nextRoot(); n != nil {
+			roots = append(roots, n)
+		}
+	}
+
+	return roots
+}
+
+//link records the socket a node was reached by, the node joins the tree of its parent
+func (t *Transversor) link(n, parent Nodes, soc *Socket) {
+	t.keys[n] = soc
+
+	if _, ok := t.roots[n]; !ok {
+		t.roots[n] = t.roots[parent]
+	}
 }
 
 //Unvisited returns the unvisited nodes
@@ -559,17 +875,181 @@ func (t *Transversor) Key() *Socket {
 	return sc
 }
 
+//shareIncoming supplies the incoming sockets of the graph so they are not rebuilt by each transversal, they are kept across resets
+func (t *Transversor) shareIncoming(incoming map[Nodes][]*Socket) {
+	t.shared = incoming
+	t.incoming = incoming
+}
+
+//nodeCache returns the cache of the node with an iterator over the sockets of its directive direction
+func (t *Transversor) nodeCache(n Nodes) *NodeCache {
+	switch t.directive.Direction {
+	case In, Both:
+		if t.incoming == nil {
+			t.incoming = incomingSockets(t.from.Graph())
+		}
+
+		socks := List()
+
+		if t.directive.Direction == Both {
+			for _, sc := range OutSockets(n) {
+				socks.AppendElement(sc)
+			}
+		}
+
+		for _, sc := range t.incoming[n] {
+			socks.AppendElement(sc)
+		}
+
+		return &NodeCache{
+			Node: n,
+			Itr:  &countingIterator{DeferIterator: socks.Iterator().(DeferIterator)},
+		}
+	default:
+		return &NodeCache{
+			Node: n,
+			Itr:  &countingIterator{DeferIterator: n.Arcs()},
+		}
+	}
+}
+
+//Parent returns the node the giving node was reached from, nil if it is a start node or has not been reached
+func (t *Transversor) Parent(n Nodes) Nodes {
+	if root, ok := t.roots[n]; !ok || root == n {
+		return nil
+	}
+
+	sc, ok := t.keys[n]
+	if !ok {
+		return nil
+	}
+
+	return peer(sc, n)
+}
+
+//PathTo returns the sockets leading from the start node of its tree to the giving node, an empty path for a start node and nil if the node has not been visited
+func (t *Transversor) PathTo(n Nodes) []*Socket {
+	if n == nil || !t.visited.Valid(n) {
+		return nil
+	}
+
+	root := t.roots[n]
+	path := []*Socket{}
+	seen := VisitMaps()
+
+	for cur := n; cur != root; {
+		sc, ok := t.keys[cur]
+		if !ok || seen[cur] {
+			return nil
+		}
+
+		seen[cur] = true
+		path = append(path, sc)
+		cur = peer(sc, cur)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+//BFSTree returns the tree discovered by the transversal so far as a new graph with sockets leading from parent to child, for breadth-first orders this is a shortest-path tree of the start node
+func (t *Transversor) BFSTree() *Graph {
+	tree := NewGraph()
+
+	if t.from == nil {
+		return tree
+	}
+
+	var reached []Nodes
+
+	if gr := t.from.Graph(); gr != nil {
+		gr.nodeSet().EachNode(func(n Nodes) {
+			if t.visited.Valid(n) {
+				reached = append(reached, n)
+			}
+		})
+	} else {
+		for n := range t.visited {
+			reached = append(reached, n)
+		}
+	}
+
+	copies := make(map[Nodes]Nodes, len(reached))
+
+	for _, n := range reached {
+		cp := NewGraphNode(n.Value(), tree)
+		tree.AddNode(cp)
+		copies[n] = cp
+	}
+
+	for _, n := range reached {
+		if t.Parent(n) == nil {
+			continue
+		}
+
+		sc := t.keys[n]
+		parent, ok := copies[t.Parent(n)]
+		if !ok {
+			continue
+		}
+
+		so := parent.Connect(copies[n], sc.Weight)
+		sc.Attrs.EachString(so.Attrs.Add)
+	}
+
+	return tree
+}
+
 //Next calls the internal next
 func (t *Transversor) Next() error {
 	if t.from == nil {
 		return ErrBadNode
 	}
 
-	if t.next != nil {
-		return t.next()
+	if t.next == nil {
+		return ErrBadIterator
+	}
+
+	if t.directive.MaxVisits > 0 && t.Visits() >= t.directive.MaxVisits {
+		return ErrVisitLimit
+	}
+
+	if err := t.next(); err != nil {
+		return err
+	}
+
+	atomic.AddInt64(&t.visits, 1)
+	return nil
+}
+
+//NextContext calls the internal next, returning the context error once the context is done
+func (t *Transversor) NextContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	t.ctx = ctx
+	defer func() {
+		t.ctx = nil
+	}()
+
+	return t.Next()
+}
+
+//interrupted returns the error of the context of the running NextContext call if any
+func (t *Transversor) interrupted() error {
+	if t.ctx == nil {
+		return nil
 	}
+	return t.ctx.Err()
+}
 
-	return ErrBadIterator
+//Visits returns the total nodes visited by the Transversor
+func (t *Transversor) Visits() int {
+	return int(atomic.LoadInt64(&t.visits))
 }
 
 //Node returns the current  node
@@ -582,8 +1062,15 @@ func (t *Transversor) Reset() {
 	t.from = nil
 	t.started = 0
 	t.walkdepth = 0
+	t.visits = 0
 	t.current = nil
 	t.keys = make(map[Nodes]*Socket)
+	t.roots = make(map[Nodes]Nodes)
+	t.incoming = t.shared
+	t.sources = nil
+	t.forest = nil
+	t.sourced = 0
+	t.forested = 0
 	t.visited.Reset()
 	if t.reset != nil {
 		t.reset()
@@ -627,6 +1114,53 @@ func (p *GraphProc) Use(n Nodes) {
 	p.trans.Use(n)
 }
 
+//UseMany sets the nodes for transversal, nodes outside the graph of the first are ignored
+func (p *GraphProc) UseMany(ns ...Nodes) {
+	var use []Nodes
+
+	p.g = nil
+
+	for _, n := range ns {
+		if n == nil || n.Graph() == nil {
+			continue
+		}
+
+		if p.g == nil {
+			p.g = n.Graph()
+		}
+
+		if n.Graph() == p.g {
+			use = append(use, n)
+		}
+	}
+
+	if len(use) == 0 {
+		return
+	}
+
+	p.trans.UseMany(use...)
+}
+
+//Root returns the start node of the tree the giving node was reached in
+func (p *GraphProc) Root(n Nodes) Nodes {
+	return p.trans.Root(n)
+}
+
+//Parent returns the node the giving node was reached from
+func (p *GraphProc) Parent(n Nodes) Nodes {
+	return p.trans.Parent(n)
+}
+
+//PathTo returns the sockets leading from the start node to the giving node
+func (p *GraphProc) PathTo(n Nodes) []*Socket {
+	return p.trans.PathTo(n)
+}
+
+//BFSTree returns the tree discovered by the transversal so far as a new graph
+func (p *GraphProc) BFSTree() *Graph {
+	return p.trans.BFSTree()
+}
+
 //Unvisited calls the internal transversal unvisited caller
 func (p *GraphProc) Unvisited() []Nodes {
 	return p.trans.Unvisited(p.g)
@@ -651,6 +1185,14 @@ func (p *GraphProc) Next() error {
 	return p.fx(p.trans.Node(), p.trans.Key(), p.trans.WalkDepth())
 }
 
+//NextContext calls the internal transversal next caller, stopping with the context error once the context is done
+func (p *GraphProc) NextContext(ctx context.Context) error {
+	if err := p.trans.NextContext(ctx); err != nil {
+		return err
+	}
+	return p.fx(p.trans.Node(), p.trans.Key(), p.trans.WalkDepth())
+}
+
 //NodeDop is (Node Depth Operation) provide a type for running on graph iterators
 type NodeDop func(Nodes, *Socket, int) error
 
@@ -757,6 +1299,11 @@ func (f *GraphFilter) Next() error {
 	return f.proc.Next()
 }
 
+//NextContext calls the filters tranversors next function, stopping with the context error once the context is done
+func (f *GraphFilter) NextContext(ctx context.Context) error {
+	return f.proc.NextContext(ctx)
+}
+
 //Path returns the current path-ways found by the ops
 func (f *GraphFilter) Path() []*FilterNode {
 	return f.paths
@@ -777,8 +1324,8 @@ func (f *GraphFilter) Nodes() []Nodes {
 
 // GraphSearcher defines interface rules for searches
 type GraphSearcher interface {
-	FindOne() (Nodes, error)
-	FindAll() ([]Nodes, error)
+	FindOne(EvaluateNode) (Nodes, error)
+	FindAll(EvaluateNode) ([]Nodes, error)
 }
 
 // EvaluateNode provides a function type for the linear searching algorithm
@@ -800,20 +1347,39 @@ func NewLinearGraphSearch(g Graphs) *LinearGraphSearch {
 
 // FindOne runs through and retuns the first matching result or an error
 func (nl *LinearGraphSearch) FindOne(ev EvaluateNode) (Nodes, error) {
+	return nl.FindOneContext(context.Background(), ev)
+}
+
+// FindAll runs through and retuns all eatching results or an error
+func (nl *LinearGraphSearch) FindAll(ev EvaluateNode) ([]Nodes, error) {
+	return nl.FindAllContext(context.Background(), ev)
+}
+
+// FindOneContext runs through and retuns the first matching result or an error, stopping with the context error once the context is done
+func (nl *LinearGraphSearch) FindOneContext(ctx context.Context, ev EvaluateNode) (Nodes, error) {
 	nl.ro.Lock()
 	defer nl.ro.Unlock()
 
 	var res Nodes
+	var err error
 
 	ns := nl.graph.nodeSet()
 
 	ns.Each(func(n Nodes, _ int, stop func()) {
+		if err = ctx.Err(); err != nil {
+			stop()
+			return
+		}
 		if ev(n) {
 			res = n
 			stop()
 		}
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
 	if res == nil {
 		return nil, ErrNotFound
 	}
@@ -821,21 +1387,30 @@ func (nl *LinearGraphSearch) FindOne(ev EvaluateNode) (Nodes, error) {
 	return res, nil
 }
 
-// FindAll runs through and retuns all eatching results or an error
-func (nl *LinearGraphSearch) FindAll(ev EvaluateNode) ([]Nodes, error) {
+// FindAllContext runs through and retuns all matching results or an error, stopping with the context error once the context is done
+func (nl *LinearGraphSearch) FindAllContext(ctx context.Context, ev EvaluateNode) ([]Nodes, error) {
 	nl.ro.Lock()
 	defer nl.ro.Unlock()
 
 	var res []Nodes
+	var err error
 
 	ns := nl.graph.nodeSet()
 
 	ns.Each(func(n Nodes, _ int, stop func()) {
+		if err = ctx.Err(); err != nil {
+			stop()
+			return
+		}
 		if ev(n) {
 			res = append(res, n)
 		}
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
 	if res == nil {
 		return nil, ErrNotFound
 	}
@@ -531,6 +531,7 @@ func (d *DeferList) Clear() {
 
 	d.tail = nil
 	d.root = nil
+	atomic.StoreInt64(&d.size, 0)
 }
 
 //DeferIterator provides and defines methods for defer iteratore
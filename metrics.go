@@ -0,0 +1,252 @@
+package ds
+
+import "math"
+
+//neighbourhoods returns the nodes of the graph with their undirected neighbour sets, self loops are ignored
+func neighbourhoods(g Graphs) ([]Nodes, map[Nodes]NodeMaps) {
+	nodes := g.nodeSet().AllNodes()
+	adj := make(map[Nodes]NodeMaps, len(nodes))
+
+	for _, n := range nodes {
+		adj[n] = VisitMaps()
+	}
+
+	for _, n := range nodes {
+		for _, sock := range OutSockets(n) {
+			to := sock.To
+			if to == n {
+				continue
+			}
+
+			if _, ok := adj[to]; !ok {
+				continue
+			}
+
+			adj[n][to] = true
+			adj[to][n] = true
+		}
+	}
+
+	return nodes, adj
+}
+
+//Triangles returns the number of triangles each node of the graph takes part in, edges are treated as undirected
+func Triangles(g Graphs) map[Nodes]int {
+	return triangles(neighbourhoods(g))
+}
+
+func triangles(nodes []Nodes, adj map[Nodes]NodeMaps) map[Nodes]int {
+	tris := make(map[Nodes]int, len(nodes))
+
+	for _, n := range nodes {
+		var nbs []Nodes
+		for nb := range adj[n] {
+			nbs = append(nbs, nb)
+		}
+
+		count := 0
+		for i := 0; i < len(nbs); i++ {
+			for j := i + 1; j < len(nbs); j++ {
+				if adj[nbs[i]][nbs[j]] {
+					count++
+				}
+			}
+		}
+
+		tris[n] = count
+	}
+
+	return tris
+}
+
+//ClusteringCoefficients returns the local clustering coefficient of each node of the graph
+func ClusteringCoefficients(g Graphs) map[Nodes]float64 {
+	nodes, adj := neighbourhoods(g)
+	tris := triangles(nodes, adj)
+	coef := make(map[Nodes]float64, len(tris))
+
+	for n, t := range tris {
+		k := len(adj[n])
+		if k < 2 {
+			coef[n] = 0
+			continue
+		}
+		coef[n] = float64(2*t) / float64(k*(k-1))
+	}
+
+	return coef
+}
+
+//AverageClustering returns the mean of the local clustering coefficients of the graph
+func AverageClustering(g Graphs) float64 {
+	coef := ClusteringCoefficients(g)
+
+	if len(coef) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, c := range coef {
+		total += c
+	}
+
+	return total / float64(len(coef))
+}
+
+//GlobalClustering returns the transitivity of the graph i.e the ratio of closed triplets to all connected triplets
+func GlobalClustering(g Graphs) float64 {
+	nodes, adj := neighbourhoods(g)
+	tris := triangles(nodes, adj)
+
+	var closed, triplets int
+
+	for n, t := range tris {
+		k := len(adj[n])
+		closed += t
+		triplets += k * (k - 1) / 2
+	}
+
+	if triplets == 0 {
+		return 0
+	}
+
+	return float64(closed) / float64(triplets)
+}
+
+//CoreNumbers returns the k-core number of each node of the graph, edges are treated as undirected
+func CoreNumbers(g Graphs) map[Nodes]int {
+	cores, _ := coreDecomposition(g)
+	return cores
+}
+
+//DegeneracyOrdering returns the nodes of the graph in degeneracy order (smallest remaining degree first) along with the degeneracy of the graph
+func DegeneracyOrdering(g Graphs) ([]Nodes, int) {
+	cores, order := coreDecomposition(g)
+
+	degeneracy := 0
+	for _, c := range cores {
+		if c > degeneracy {
+			degeneracy = c
+		}
+	}
+
+	return order, degeneracy
+}
+
+//coreDecomposition runs the Batagelj-Zaversnik bucket algorithm returning the core numbers and the removal order
+func coreDecomposition(g Graphs) (map[Nodes]int, []Nodes) {
+	nodes, adj := neighbourhoods(g)
+	degree := make(map[Nodes]int, len(nodes))
+
+	maxdeg := 0
+	for _, n := range nodes {
+		d := len(adj[n])
+		degree[n] = d
+		if d > maxdeg {
+			maxdeg = d
+		}
+	}
+
+	buckets := make([][]Nodes, maxdeg+1)
+	for _, n := range nodes {
+		buckets[degree[n]] = append(buckets[degree[n]], n)
+	}
+
+	cores := make(map[Nodes]int, len(nodes))
+	order := make([]Nodes, 0, len(nodes))
+	removed := VisitMaps()
+
+	for d := 0; d <= maxdeg; {
+		if len(buckets[d]) == 0 {
+			d++
+			continue
+		}
+
+		last := len(buckets[d]) - 1
+		n := buckets[d][last]
+		buckets[d] = buckets[d][:last]
+
+		if removed.Valid(n) || degree[n] != d {
+			continue
+		}
+
+		removed.Add(n)
+		cores[n] = d
+		order = append(order, n)
+
+		for nb := range adj[n] {
+			if removed.Valid(nb) || degree[nb] <= d {
+				continue
+			}
+			degree[nb]--
+			buckets[degree[nb]] = append(buckets[degree[nb]], nb)
+		}
+	}
+
+	return cores, order
+}
+
+//AverageNeighbourDegree returns the average degree of the neighbours of each node of the graph
+func AverageNeighbourDegree(g Graphs) map[Nodes]float64 {
+	nodes, adj := neighbourhoods(g)
+	avg := make(map[Nodes]float64, len(nodes))
+
+	for _, n := range nodes {
+		k := len(adj[n])
+		if k == 0 {
+			avg[n] = 0
+			continue
+		}
+
+		total := 0
+		for nb := range adj[n] {
+			total += len(adj[nb])
+		}
+
+		avg[n] = float64(total) / float64(k)
+	}
+
+	return avg
+}
+
+//DegreeAssortativity returns the degree assortativity coefficient of the graph, it returns NaN when the coefficient is undefined (e.g for graphs without edges or where all nodes share the same degree)
+func DegreeAssortativity(g Graphs) float64 {
+	nodes, adj := neighbourhoods(g)
+
+	index := make(map[Nodes]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+
+	var m, prod, sum, squares float64
+
+	for i, n := range nodes {
+		j := float64(len(adj[n]))
+
+		for nb := range adj[n] {
+			if index[nb] <= i {
+				continue
+			}
+
+			k := float64(len(adj[nb]))
+
+			m++
+			prod += j * k
+			sum += (j + k) / 2
+			squares += (j*j + k*k) / 2
+		}
+	}
+
+	if m == 0 {
+		return math.NaN()
+	}
+
+	mean := sum / m
+	denom := squares/m - mean*mean
+
+	if denom == 0 {
+		return math.NaN()
+	}
+
+	return (prod/m - mean*mean) / denom
+}
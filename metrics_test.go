@@ -0,0 +1,88 @@
+package ds
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrianglesAndClustering(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+	gs.Bind(1, 2, 0)
+	gs.Bind(2, 3, 0)
+	gs.Bind(3, 1, 0)
+	gs.Bind(3, 4, 0)
+	gs.Bind(4, 5, 0)
+
+	tris := Triangles(gs)
+
+	for _, v := range []int{1, 2, 3} {
+		if tris[gs.Get(v)] != 1 {
+			t.Fatalf("Expected node %d to be in 1 triangle got %d", v, tris[gs.Get(v)])
+		}
+	}
+
+	if tris[gs.Get(4)] != 0 {
+		t.Fatalf("Expected node 4 to be in no triangle got %d", tris[gs.Get(4)])
+	}
+
+	coef := ClusteringCoefficients(gs)
+
+	if coef[gs.Get(1)] != 1 {
+		t.Fatalf("Expected clustering of 1 to be 1 got %f", coef[gs.Get(1)])
+	}
+
+	if c := coef[gs.Get(3)]; math.Abs(c-1.0/3.0) > 1e-9 {
+		t.Fatalf("Expected clustering of 3 to be 1/3 got %f", c)
+	}
+
+	if c := GlobalClustering(gs); math.Abs(c-0.5) > 1e-9 {
+		t.Fatalf("Expected transitivity of 0.5 got %f", c)
+	}
+}
+
+func TestCoreNumbers(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+	gs.Bind(1, 2, 0)
+	gs.Bind(2, 3, 0)
+	gs.Bind(3, 1, 0)
+	gs.Bind(3, 4, 0)
+	gs.Bind(4, 5, 0)
+
+	cores := CoreNumbers(gs)
+
+	expected := map[int]int{1: 2, 2: 2, 3: 2, 4: 1, 5: 1}
+
+	for v, c := range expected {
+		if cores[gs.Get(v)] != c {
+			t.Fatalf("Expected core number %d for %d got %d", c, v, cores[gs.Get(v)])
+		}
+	}
+
+	order, degeneracy := DegeneracyOrdering(gs)
+
+	if degeneracy != 2 {
+		t.Fatalf("Expected degeneracy of 2 got %d", degeneracy)
+	}
+
+	if len(order) != gs.Length() {
+		t.Fatalf("Expected ordering of %d nodes got %d", gs.Length(), len(order))
+	}
+}
+
+func TestDegreeAssortativity(t *testing.T) {
+	star := NewGraph()
+	star.Add(0, 1, 2, 3)
+	star.Bind(0, 1, 0)
+	star.Bind(0, 2, 0)
+	star.Bind(0, 3, 0)
+
+	if r := DegreeAssortativity(star); math.Abs(r+1) > 1e-9 {
+		t.Fatalf("Expected a star to be fully disassortative got %f", r)
+	}
+
+	if r := DegreeAssortativity(NewGraph()); !math.IsNaN(r) {
+		t.Fatalf("Expected NaN for an empty graph got %f", r)
+	}
+}
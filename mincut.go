@@ -0,0 +1,287 @@
+package ds
+
+//Cut provides a partition of the nodes of a graph with the total weight of the sockets crossing it
+type Cut struct {
+	Weight int
+	Left   []Nodes
+	Right  []Nodes
+}
+
+//capacities returns the nodes of the graph and the symmetric capacity matrix made from the socket weights, sockets in both directions between two nodes are summed and self loops are ignored
+func capacities(g Graphs) ([]Nodes, [][]int) {
+	nodes := g.nodeSet().AllNodes()
+	index := make(map[Nodes]int, len(nodes))
+
+	for i, n := range nodes {
+		index[n] = i
+	}
+
+	caps := make([][]int, len(nodes))
+	for i := range caps {
+		caps[i] = make([]int, len(nodes))
+	}
+
+	for i, n := range nodes {
+		for _, sock := range OutSockets(n) {
+			j, ok := index[sock.To]
+			if !ok || i == j {
+				continue
+			}
+			caps[i][j] += sock.Weight
+			caps[j][i] += sock.Weight
+		}
+	}
+
+	return nodes, caps
+}
+
+func makeCut(nodes []Nodes, weight int, left []bool) *Cut {
+	cut := &Cut{Weight: weight}
+
+	for i, n := range nodes {
+		if left[i] {
+			cut.Left = append(cut.Left, n)
+		} else {
+			cut.Right = append(cut.Right, n)
+		}
+	}
+
+	return cut
+}
+
+//MinCut returns the global minimum cut of the graph using the Stoer-Wagner algorithm, sockets are treated as undirected edges with their weight as capacity and weights must not be negative
+func MinCut(g Graphs) (*Cut, error) {
+	nodes, caps := capacities(g)
+	size := len(nodes)
+
+	if size < 2 {
+		return nil, ErrEmpty
+	}
+
+	groups := make([][]int, size)
+	active := make([]bool, size)
+
+	for i := range groups {
+		groups[i] = []int{i}
+		active[i] = true
+	}
+
+	best := -1
+	var bestGroup []int
+
+	for phase := size; phase > 1; phase-- {
+		weights := make([]int, size)
+		added := make([]bool, size)
+		prev, last := -1, -1
+
+		for k := 0; k < phase; k++ {
+			sel := -1
+			for v := 0; v < size; v++ {
+				if !active[v] || added[v] {
+					continue
+				}
+				if sel == -1 || weights[v] > weights[sel] {
+					sel = v
+				}
+			}
+
+			added[sel] = true
+			prev, last = last, sel
+
+			for v := 0; v < size; v++ {
+				if active[v] && !added[v] {
+					weights[v] += caps[sel][v]
+				}
+			}
+		}
+
+		if best == -1 || weights[last] < best {
+			best = weights[last]
+			bestGroup = append([]int(nil), groups[last]...)
+		}
+
+		groups[prev] = append(groups[prev], groups[last]...)
+		for v := 0; v < size; v++ {
+			caps[prev][v] += caps[last][v]
+			caps[v][prev] = caps[prev][v]
+		}
+		active[last] = false
+	}
+
+	left := make([]bool, size)
+	for _, i := range bestGroup {
+		left[i] = true
+	}
+
+	return makeCut(nodes, best, left), nil
+}
+
+//maxFlow returns the maximum flow between s and t over the capacity matrix using Edmonds-Karp and the set of nodes reachable from s in the final residual graph
+func maxFlow(caps [][]int, s, t int) (int, []bool) {
+	size := len(caps)
+	residual := make([][]int, size)
+
+	for i := range caps {
+		residual[i] = append([]int(nil), caps[i]...)
+	}
+
+	flow := 0
+
+	for {
+		parent := make([]int, size)
+		for i := range parent {
+			parent[i] = -1
+		}
+		parent[s] = s
+
+		queue := []int{s}
+		for len(queue) > 0 && parent[t] == -1 {
+			u := queue[0]
+			queue = queue[1:]
+
+			for v := 0; v < size; v++ {
+				if parent[v] == -1 && residual[u][v] > 0 {
+					parent[v] = u
+					queue = append(queue, v)
+				}
+			}
+		}
+
+		if parent[t] == -1 {
+			reach := make([]bool, size)
+			for i, p := range parent {
+				reach[i] = p != -1
+			}
+			return flow, reach
+		}
+
+		push := -1
+		for v := t; v != s; v = parent[v] {
+			if c := residual[parent[v]][v]; push == -1 || c < push {
+				push = c
+			}
+		}
+
+		for v := t; v != s; v = parent[v] {
+			residual[parent[v]][v] -= push
+			residual[v][parent[v]] += push
+		}
+
+		flow += push
+	}
+}
+
+//GomoryHuTree provides a cut tree answering minimum cut queries between any two nodes of a graph
+type GomoryHuTree struct {
+	nodes  []Nodes
+	index  map[Nodes]int
+	parent []int
+	weight []int
+}
+
+//NewGomoryHuTree builds the Gomory-Hu tree of the graph using Gusfield's algorithm, sockets are treated as undirected edges with their weight as capacity
+func NewGomoryHuTree(g Graphs) (*GomoryHuTree, error) {
+	nodes, caps := capacities(g)
+	size := len(nodes)
+
+	if size < 2 {
+		return nil, ErrEmpty
+	}
+
+	parent := make([]int, size)
+	weight := make([]int, size)
+
+	for s := 1; s < size; s++ {
+		t := parent[s]
+		flow, side := maxFlow(caps, s, t)
+		weight[s] = flow
+
+		for i := 0; i < size; i++ {
+			if i != s && side[i] && parent[i] == t {
+				parent[i] = s
+			}
+		}
+
+		if side[parent[t]] {
+			parent[s] = parent[t]
+			parent[t] = s
+			weight[s] = weight[t]
+			weight[t] = flow
+		}
+	}
+
+	index := make(map[Nodes]int, size)
+	for i, n := range nodes {
+		index[n] = i
+	}
+
+	return &GomoryHuTree{
+		nodes:  nodes,
+		index:  index,
+		parent: parent,
+		weight: weight,
+	}, nil
+}
+
+//root returns true if the index is the root of the tree
+func (t *GomoryHuTree) root(i int) bool {
+	return t.parent[i] == i
+}
+
+//MinCut returns the weight of the minimum cut separating both nodes
+func (t *GomoryHuTree) MinCut(a, b Nodes) (int, error) {
+	ai, aok := t.index[a]
+	bi, bok := t.index[b]
+
+	if !aok || !bok {
+		return 0, ErrBadNode
+	}
+
+	if ai == bi {
+		return 0, ErrBadIndex
+	}
+
+	mins := map[int]int{ai: -1}
+
+	for cur, min := ai, -1; !t.root(cur); {
+		if w := t.weight[cur]; min == -1 || w < min {
+			min = w
+		}
+		cur = t.parent[cur]
+		mins[cur] = min
+	}
+
+	min := -1
+	for cur := bi; ; cur = t.parent[cur] {
+		if amin, ok := mins[cur]; ok {
+			if amin != -1 && (min == -1 || amin < min) {
+				min = amin
+			}
+			return min, nil
+		}
+
+		if w := t.weight[cur]; min == -1 || w < min {
+			min = w
+		}
+	}
+}
+
+//Graph returns the tree as a new graph with sockets from each node to its tree parent weighted by the minimum cut between them
+func (t *GomoryHuTree) Graph() *Graph {
+	tree := NewGraph()
+	copies := make([]Nodes, len(t.nodes))
+
+	for i, n := range t.nodes {
+		copies[i] = NewGraphNode(n.Value(), tree)
+		tree.AddNode(copies[i])
+	}
+
+	for i := range t.nodes {
+		if t.root(i) {
+			continue
+		}
+		copies[i].Connect(copies[t.parent[i]], t.weight[i])
+	}
+
+	return tree
+}
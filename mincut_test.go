@@ -0,0 +1,77 @@
+package ds
+
+import "testing"
+
+func TestMinCut(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5, 6)
+	gs.Bind(1, 2, 3)
+	gs.Bind(2, 3, 4)
+	gs.Bind(3, 1, 5)
+	gs.Bind(4, 5, 6)
+	gs.Bind(5, 6, 2)
+	gs.Bind(6, 4, 3)
+	gs.Bind(3, 4, 1)
+	gs.Bind(4, 3, 1)
+
+	cut, err := MinCut(gs)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cut.Weight != 2 {
+		t.Fatalf("Expected a minimum cut of 2 got %d", cut.Weight)
+	}
+
+	if len(cut.Left)+len(cut.Right) != gs.Length() || len(cut.Left) != 3 {
+		t.Fatalf("Expected two partitions of 3 nodes got %s and %s", cut.Left, cut.Right)
+	}
+
+	if _, err := MinCut(NewGraph()); err != ErrEmpty {
+		t.Fatalf("Expected ErrEmpty for an empty graph got %v", err)
+	}
+}
+
+func TestGomoryHuTree(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5, 6)
+	gs.Bind(1, 2, 3)
+	gs.Bind(2, 3, 4)
+	gs.Bind(3, 1, 5)
+	gs.Bind(4, 5, 6)
+	gs.Bind(5, 6, 2)
+	gs.Bind(6, 4, 3)
+	gs.Bind(3, 4, 1)
+	gs.Bind(4, 3, 1)
+
+	tree, err := NewGomoryHuTree(gs)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		a, b, cut int
+	}{
+		{1, 6, 2},
+		{1, 2, 7},
+		{2, 3, 7},
+		{4, 6, 5},
+		{5, 6, 5},
+	}
+
+	for _, c := range cases {
+		w, err := tree.MinCut(gs.Get(c.a), gs.Get(c.b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w != c.cut {
+			t.Fatalf("Expected minimum cut of %d between %d and %d got %d", c.cut, c.a, c.b, w)
+		}
+	}
+
+	if tg := tree.Graph(); tg.Length() != gs.Length() {
+		t.Fatalf("Expected tree graph of %d nodes got %d", gs.Length(), tg.Length())
+	}
+}
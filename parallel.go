@@ -0,0 +1,173 @@
+package ds
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+//parallelChunk is the number of frontier nodes a worker takes at a time
+const parallelChunk = 256
+
+//ParallelOptions configures a ParallelBFS
+type ParallelOptions struct {
+	//Workers sets the number of goroutines expanding each frontier, zero uses GOMAXPROCS
+	Workers int
+	//LastDepth is the deepest level of nodes visited, zero means no limit, where TransversalDirective.Depth bounds the walk depth of an order
+	LastDepth int
+	//Direction sets which sockets are followed, an empty Direction means Out
+	Direction Direction
+	//Deterministic claims nodes in frontier order and calls the NodeDop from a single goroutine, giving the same visits and sockets on every run, otherwise the NodeDop must be safe for concurrent use
+	Deterministic bool
+}
+
+//frontierItem is a node of a frontier with the socket it was reached by
+type frontierItem struct {
+	node   Nodes
+	socket *Socket
+}
+
+//ParallelBFS runs a level-synchronous breadth first search from the start nodes on a pool of workers, returning the first error of the NodeDop or the context
+func ParallelBFS(ctx context.Context, fx NodeDop, opts ParallelOptions, starts ...Nodes) error {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var visited sync.Map
+	var incoming map[Nodes][]*Socket
+	var frontier []frontierItem
+
+	for _, n := range starts {
+		if n == nil {
+			continue
+		}
+
+		if incoming == nil && opts.Direction != Out && opts.Direction != "" {
+			incoming = incomingSockets(n.Graph())
+		}
+
+		if _, loaded := visited.LoadOrStore(n, true); !loaded {
+			frontier = append(frontier, frontierItem{node: n})
+		}
+	}
+
+	var failed error
+	var once sync.Once
+
+	fail := func(err error) {
+		once.Do(func() {
+			failed = err
+			cancel()
+		})
+	}
+
+	for depth := 0; len(frontier) > 0; depth++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		expand := opts.LastDepth <= 0 || depth < opts.LastDepth
+
+		if opts.Deterministic {
+			for _, it := range frontier {
+				if err := fx(it.node, it.socket, depth); err != nil {
+					return err
+				}
+			}
+
+			if !expand {
+				break
+			}
+		}
+
+		chunks := (len(frontier) + parallelChunk - 1) / parallelChunk
+		found := make([][]frontierItem, chunks)
+		cursor := int64(-1)
+
+		var wg sync.WaitGroup
+
+		for w := 0; w < workers && w < chunks; w++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for {
+					c := int(atomic.AddInt64(&cursor, 1))
+					if c >= chunks {
+						return
+					}
+
+					end := (c + 1) * parallelChunk
+					if end > len(frontier) {
+						end = len(frontier)
+					}
+
+					for _, it := range frontier[c*parallelChunk : end] {
+						if ctx.Err() != nil {
+							return
+						}
+
+						if !opts.Deterministic {
+							if err := fx(it.node, it.socket, depth); err != nil {
+								fail(err)
+								return
+							}
+						}
+
+						if !expand {
+							continue
+						}
+
+						for _, soc := range directedSockets(it.node, opts.Direction, incoming) {
+							co := peer(soc, it.node)
+
+							if opts.Deterministic {
+								if _, ok := visited.Load(co); !ok {
+									found[c] = append(found[c], frontierItem{node: co, socket: soc})
+								}
+								continue
+							}
+
+							if _, loaded := visited.LoadOrStore(co, true); !loaded {
+								found[c] = append(found[c], frontierItem{node: co, socket: soc})
+							}
+						}
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if failed != nil {
+			return failed
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var next []frontierItem
+
+		for _, items := range found {
+			for _, it := range items {
+				if opts.Deterministic {
+					if _, loaded := visited.LoadOrStore(it.node, true); loaded {
+						continue
+					}
+				}
+				next = append(next, it)
+			}
+		}
+
+		frontier = next
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package ds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelBFSDeterministic(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+	gs.Bind(1, 2, 0)
+	gs.Bind(1, 3, 0)
+	gs.Bind(2, 4, 0)
+	gs.Bind(3, 4, 0)
+	gs.Bind(4, 5, 0)
+
+	for i := 0; i < 10; i++ {
+		var order []string
+
+		err := ParallelBFS(context.Background(), func(n Nodes, soc *Socket, depth int) error {
+			from := "-"
+			if soc != nil {
+				from = fmt.Sprint(soc.From.Value())
+			}
+			order = append(order, fmt.Sprintf("%v:%s:%d", n.Value(), from, depth))
+			return nil
+		}, ParallelOptions{Workers: 4, Deterministic: true}, gs.Get(1))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if fmt.Sprint(order) != "[1:-:0 2:1:1 3:1:1 4:2:2 5:4:3]" {
+			t.Fatalf("Unexpected deterministic order %v", order)
+		}
+	}
+}
+
+func TestParallelBFSConcurrent(t *testing.T) {
+	_, root := wideGraph(10000)
+
+	var count, deep int64
+
+	err := ParallelBFS(context.Background(), func(n Nodes, soc *Socket, depth int) error {
+		atomic.AddInt64(&count, 1)
+		if depth > 1 {
+			atomic.AddInt64(&deep, 1)
+		}
+		return nil
+	}, ParallelOptions{Workers: 8}, root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 10000 || deep != 0 {
+		t.Fatalf("Expected 10000 nodes within depth 1 got %d with %d deeper", count, deep)
+	}
+}
+
+func TestParallelBFSOptions(t *testing.T) {
+	gs, root := chainGraph(1000)
+
+	var count int64
+
+	err := ParallelBFS(context.Background(), func(Nodes, *Socket, int) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	}, ParallelOptions{LastDepth: 10}, root)
+
+	if err != nil || count != 11 {
+		t.Fatalf("Expected 11 nodes up to depth 10 got %d: %v", count, err)
+	}
+
+	last := gs.nodeSet().AllNodes()[999]
+	count = 0
+
+	err = ParallelBFS(context.Background(), func(Nodes, *Socket, int) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	}, ParallelOptions{Direction: In}, last)
+
+	if err != nil || count != 1000 {
+		t.Fatalf("Expected to walk the chain backwards got %d: %v", count, err)
+	}
+
+	stop := errors.New("stop")
+
+	err = ParallelBFS(context.Background(), func(n Nodes, _ *Socket, depth int) error {
+		if depth == 5 {
+			return stop
+		}
+		return nil
+	}, ParallelOptions{}, root)
+
+	if err != stop {
+		t.Fatalf("Expected the callback error got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := ParallelBFS(ctx, func(Nodes, *Socket, int) error {
+		return nil
+	}, ParallelOptions{}, root); err != context.Canceled {
+		t.Fatalf("Expected context error got %v", err)
+	}
+}
+
+func TestParallelBFSLastDepth(t *testing.T) {
+	_, root := chainGraph(50)
+
+	for _, limit := range []int{1, 2, 10} {
+		dir := BFPreOrderDirective(nil, nil)
+		dir.Depth = limit
+
+		trans, _ := CreateGraphTransversor(dir)
+		trans.Use(root)
+
+		var walked []interface{}
+		for trans.Next() == nil {
+			walked = append(walked, trans.Node().Value())
+		}
+
+		var visited []interface{}
+
+		err := ParallelBFS(context.Background(), func(n Nodes, _ *Socket, _ int) error {
+			visited = append(visited, n.Value())
+			return nil
+		}, ParallelOptions{LastDepth: limit, Deterministic: true}, root)
+
+		if err != nil || fmt.Sprint(visited) != fmt.Sprint(walked) {
+			t.Fatalf("Expected LastDepth %d to match the breadth first walk of a chain %v got %v: %v", limit, walked, visited, err)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package ds
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//rpqTrans is a labelled move of a path automaton, a wildcard matches any socket
+type rpqTrans struct {
+	label string
+	any   bool
+	to    int
+}
+
+//rpqState is a state of a path automaton
+type rpqState struct {
+	eps   []int
+	trans []rpqTrans
+}
+
+//rpqFrag is a piece of automaton under construction with a single entry and exit
+type rpqFrag struct {
+	start, end int
+}
+
+//rpqParser builds a Thompson automaton from a path expression
+type rpqParser struct {
+	src    []rune
+	at     int
+	states []rpqState
+}
+
+func (p *rpqParser) state() int {
+	p.states = append(p.states, rpqState{})
+	return len(p.states) - 1
+}
+
+func (p *rpqParser) eps(from, to int) {
+	p.states[from].eps = append(p.states[from].eps, to)
+}
+
+func (p *rpqParser) skip() {
+	for p.at < len(p.src) && unicode.IsSpace(p.src[p.at]) {
+		p.at++
+	}
+}
+
+//peek returns the next non space rune, zero at the end
+func (p *rpqParser) peek() rune {
+	p.skip()
+	if p.at >= len(p.src) {
+		return 0
+	}
+	return p.src[p.at]
+}
+
+func (p *rpqParser) fail(msg string) error {
+	return fmt.Errorf("Path query syntax error at %d: %s", p.at, msg)
+}
+
+func isLabelRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == ':'
+}
+
+func (p *rpqParser) parseAlt() (rpqFrag, error) {
+	left, err := p.parseConcat()
+	if err != nil {
+		return left, err
+	}
+
+	for p.peek() == '|' {
+		p.at++
+
+		right, err := p.parseConcat()
+		if err != nil {
+			return right, err
+		}
+
+		s, e := p.state(), p.state()
+		p.eps(s, left.start)
+		p.eps(s, right.start)
+		p.eps(left.end, e)
+		p.eps(right.end, e)
+
+		left = rpqFrag{s, e}
+	}
+
+	return left, nil
+}
+
+func (p *rpqParser) parseConcat() (rpqFrag, error) {
+	left, err := p.parseRepeat()
+	if err != nil {
+		return left, err
+	}
+
+	for {
+		r := p.peek()
+
+		if r == '.' {
+			p.at++
+		} else if r != '(' && !isLabelRune(r) {
+			return left, nil
+		}
+
+		right, err := p.parseRepeat()
+		if err != nil {
+			return right, err
+		}
+
+		p.eps(left.end, right.start)
+		left = rpqFrag{left.start, right.end}
+	}
+}
+
+func (p *rpqParser) parseRepeat() (rpqFrag, error) {
+	frag, err := p.parseAtom()
+	if err != nil {
+		return frag, err
+	}
+
+	for {
+		switch p.peek() {
+		case '*':
+			p.at++
+			s, e := p.state(), p.state()
+			p.eps(s, frag.start)
+			p.eps(s, e)
+			p.eps(frag.end, frag.start)
+			p.eps(frag.end, e)
+			frag = rpqFrag{s, e}
+		case '+':
+			p.at++
+			e := p.state()
+			p.eps(frag.end, frag.start)
+			p.eps(frag.end, e)
+			frag = rpqFrag{frag.start, e}
+		case '?':
+			p.at++
+			s, e := p.state(), p.state()
+			p.eps(s, frag.start)
+			p.eps(s, e)
+			p.eps(frag.end, e)
+			frag = rpqFrag{s, e}
+		default:
+			return frag, nil
+		}
+	}
+}
+
+func (p *rpqParser) parseAtom() (rpqFrag, error) {
+	r := p.peek()
+
+	if r == '(' {
+		p.at++
+
+		frag, err := p.parseAlt()
+		if err != nil {
+			return frag, err
+		}
+
+		if p.peek() != ')' {
+			return frag, p.fail("expected )")
+		}
+
+		p.at++
+		return frag, nil
+	}
+
+	if !isLabelRune(r) {
+		if r == 0 {
+			return rpqFrag{}, p.fail("expected a label")
+		}
+		return rpqFrag{}, p.fail(fmt.Sprintf("unexpected %q", r))
+	}
+
+	start := p.at
+	for p.at < len(p.src) && isLabelRune(p.src[p.at]) {
+		p.at++
+	}
+
+	label := string(p.src[start:p.at])
+
+	s, e := p.state(), p.state()
+	p.states[s].trans = append(p.states[s].trans, rpqTrans{
+		label: label,
+		any:   label == "_",
+		to:    e,
+	})
+
+	return rpqFrag{s, e}, nil
+}
+
+//PathMatch is a path matching a PathQuery along with its end nodes
+type PathMatch struct {
+	From, To Nodes
+	Path     []*Socket
+}
+
+//PathQuery provides a regular path query over the attributes of outgoing sockets, `.` joins steps and `_` matches any socket, e.g. `calls+ . reads`, inverse steps are not supported
+type PathQuery struct {
+	expr    string
+	states  []rpqState
+	start   int
+	accept  int
+	closure [][]int
+}
+
+//CompilePathQuery parses the expression into a PathQuery
+func CompilePathQuery(expr string) (*PathQuery, error) {
+	p := &rpqParser{src: []rune(expr)}
+
+	frag, err := p.parseAlt()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() != 0 {
+		return nil, p.fail(fmt.Sprintf("unexpected %q", p.peek()))
+	}
+
+	q := &PathQuery{
+		expr:   strings.TrimSpace(expr),
+		states: p.states,
+		start:  frag.start,
+		accept: frag.end,
+	}
+
+	q.closure = make([][]int, len(q.states))
+
+	for s := range q.states {
+		seen := map[int]bool{s: true}
+		stack := []int{s}
+
+		for len(stack) > 0 {
+			cur := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			q.closure[s] = append(q.closure[s], cur)
+
+			for _, nx := range q.states[cur].eps {
+				if !seen[nx] {
+					seen[nx] = true
+					stack = append(stack, nx)
+				}
+			}
+		}
+	}
+
+	return q, nil
+}
+
+//String returns the expression of the query
+func (q *PathQuery) String() string {
+	return q.expr
+}
+
+//rpqPair is a node of the product of the graph with the automaton
+type rpqPair struct {
+	node  Nodes
+	state int
+}
+
+//rpqStep records how a product pair was reached
+type rpqStep struct {
+	prev   rpqPair
+	socket *Socket
+}
+
+//search walks the product of the graph and the automaton breadth first from the start node, calling fx with the shortest matching path to each end node until fx returns false
+func (q *PathQuery) search(start Nodes, fx func(PathMatch) bool) {
+	if start == nil {
+		return
+	}
+
+	steps := make(map[rpqPair]rpqStep)
+	ended := VisitMaps()
+
+	var queue []rpqPair
+
+	for _, s := range q.closure[q.start] {
+		pair := rpqPair{start, s}
+		steps[pair] = rpqStep{}
+		queue = append(queue, pair)
+	}
+
+	pathOf := func(pair rpqPair) []*Socket {
+		var path []*Socket
+
+		for cur := pair; steps[cur].socket != nil; cur = steps[cur].prev {
+			path = append(path, steps[cur].socket)
+		}
+
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+
+		return path
+	}
+
+	for _, pair := range queue {
+		if pair.state == q.accept {
+			ended[start] = true
+			if !fx(PathMatch{From: start, To: start, Path: []*Socket{}}) {
+				return
+			}
+			break
+		}
+	}
+
+	for len(queue) > 0 {
+		pair := queue[0]
+		queue = queue[1:]
+
+		for _, tr := range q.states[pair.state].trans {
+			for _, sc := range OutSockets(pair.node) {
+				if !tr.any && (sc.Attrs == nil || !sc.Attrs.Has(tr.label)) {
+					continue
+				}
+
+				for _, s := range q.closure[tr.to] {
+					next := rpqPair{sc.To, s}
+
+					if _, ok := steps[next]; ok {
+						continue
+					}
+
+					steps[next] = rpqStep{prev: pair, socket: sc}
+					queue = append(queue, next)
+
+					if s == q.accept && !ended[sc.To] {
+						ended[sc.To] = true
+						if !fx(PathMatch{From: start, To: sc.To, Path: pathOf(next)}) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+//From returns every node reachable from the start node along a matching path with the shortest such path, in order of path length
+func (q *PathQuery) From(start Nodes) []PathMatch {
+	var matches []PathMatch
+
+	q.search(start, func(m PathMatch) bool {
+		matches = append(matches, m)
+		return true
+	})
+
+	return matches
+}
+
+//Reachable returns the nodes reachable from the start node along a matching path
+func (q *PathQuery) Reachable(start Nodes) []Nodes {
+	var nodes []Nodes
+
+	q.search(start, func(m PathMatch) bool {
+		nodes = append(nodes, m.To)
+		return true
+	})
+
+	return nodes
+}
+
+//Match returns the shortest matching path between the nodes, false if there is none
+func (q *PathQuery) Match(from, to Nodes) ([]*Socket, bool) {
+	var path []*Socket
+	found := false
+
+	q.search(from, func(m PathMatch) bool {
+		if m.To != to {
+			return true
+		}
+
+		path, found = m.Path, true
+		return false
+	})
+
+	return path, found
+}
+
+//Pairs returns every pair of nodes of the graph joined by a matching path with the shortest such path, grouped by start node in graph order
+func (q *PathQuery) Pairs(g Graphs) []PathMatch {
+	var matches []PathMatch
+
+	if g == nil {
+		return nil
+	}
+
+	for _, n := range g.nodeSet().AllNodes() {
+		matches = append(matches, q.From(n)...)
+	}
+
+	return matches
+}
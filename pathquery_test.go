@@ -0,0 +1,156 @@
+package ds
+
+import (
+	"fmt"
+	"testing"
+)
+
+func values(ns []Nodes) string {
+	var vals []interface{}
+	for _, n := range ns {
+		vals = append(vals, n.Value())
+	}
+	return fmt.Sprint(vals)
+}
+
+func TestPathQueryReachable(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("web", "svc", "worker", "users", "audit", "admin")
+
+	connect := func(a, b, attr string) {
+		gs.Get(a).Connect(gs.Get(b), 1).Attrs.Add(attr)
+	}
+
+	connect("web", "svc", "calls")
+	connect("svc", "worker", "calls")
+	connect("worker", "users", "reads")
+	connect("svc", "audit", "writes")
+	connect("admin", "users", "reads")
+	connect("worker", "svc", "calls")
+
+	cases := []struct {
+		expr   string
+		start  string
+		expect string
+	}{
+		{"calls+ . reads", "web", "[users]"},
+		{"calls reads", "web", "[]"},
+		{"calls*", "web", "[web svc worker]"},
+		{"calls . (reads|writes)", "web", "[audit]"},
+		{"calls+ . (reads|writes)", "web", "[audit users]"},
+		{"calls . calls? . writes", "web", "[audit]"},
+		{"_ . _", "web", "[worker audit]"},
+		{"calls+", "svc", "[worker svc]"},
+		{"reads", "admin", "[users]"},
+	}
+
+	for _, c := range cases {
+		q, err := CompilePathQuery(c.expr)
+
+		if err != nil {
+			t.Fatalf("%s: %s", c.expr, err)
+		}
+
+		if got := values(q.Reachable(gs.Get(c.start))); got != c.expect {
+			t.Fatalf("%s from %s: expected %s got %s", c.expr, c.start, c.expect, got)
+		}
+	}
+}
+
+func TestPathQueryPaths(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("web", "svc", "worker", "users", "audit", "admin")
+
+	connect := func(a, b, attr string) {
+		gs.Get(a).Connect(gs.Get(b), 1).Attrs.Add(attr)
+	}
+
+	connect("web", "svc", "calls")
+	connect("svc", "worker", "calls")
+	connect("worker", "users", "reads")
+	connect("svc", "audit", "writes")
+	connect("admin", "users", "reads")
+	connect("worker", "svc", "calls")
+
+	q, err := CompilePathQuery("calls+ . reads")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, ok := q.Match(gs.Get("web"), gs.Get("users"))
+
+	if !ok || len(path) != 3 || path[2].From.Value() != "worker" {
+		t.Fatalf("Expected a 3 socket witness got %d", len(path))
+	}
+
+	if _, ok := q.Match(gs.Get("admin"), gs.Get("users")); ok {
+		t.Fatal("Expected admin to reach users without calls")
+	}
+
+	var pairs []string
+	for _, m := range q.Pairs(gs) {
+		pairs = append(pairs, fmt.Sprintf("%v>%v:%d", m.From.Value(), m.To.Value(), len(m.Path)))
+	}
+
+	if fmt.Sprint(pairs) != "[web>users:3 svc>users:2 worker>users:3]" {
+		t.Fatalf("Unexpected pairs %v", pairs)
+	}
+
+	star, _ := CompilePathQuery("calls*")
+	matches := star.From(gs.Get("web"))
+
+	if len(matches) != 3 || len(matches[0].Path) != 0 || matches[0].To.Value() != "web" {
+		t.Fatal("Expected the empty path to match the start node first")
+	}
+}
+
+func TestPathQuerySyntax(t *testing.T) {
+	bad := []string{"", "calls .", "(calls", "calls | ", "*calls", "calls)", "a $ b"}
+
+	for _, expr := range bad {
+		if _, err := CompilePathQuery(expr); err == nil {
+			t.Fatalf("Expected %q to fail", expr)
+		}
+	}
+
+	q, err := CompilePathQuery("  (owns|member)* . grants ")
+
+	if err != nil || q.String() != "(owns|member)* . grants" {
+		t.Fatalf("Unexpected compile %v %v", q, err)
+	}
+}
+
+func TestPathQueryConcatenation(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("web", "svc", "proxy", "users")
+
+	gs.Get("web").Connect(gs.Get("svc"), 1).Attrs.Add("calls")
+	gs.Get("svc").Connect(gs.Get("proxy"), 1).Attrs.Add("forwards")
+	gs.Get("proxy").Connect(gs.Get("users"), 1).Attrs.Add("reads")
+	gs.Get("users").Connect(gs.Get("web"), 1).Attrs.Add("reads")
+
+	cases := []struct {
+		expr   string
+		start  string
+		expect string
+	}{
+		{"calls+ . reads", "web", "[]"},
+		{"calls+ . _ . reads", "web", "[users]"},
+		{"calls+ . forwards . reads", "web", "[users]"},
+		{"reads", "web", "[]"},
+		{"reads", "users", "[web]"},
+	}
+
+	for _, c := range cases {
+		q, err := CompilePathQuery(c.expr)
+
+		if err != nil {
+			t.Fatalf("%s: %s", c.expr, err)
+		}
+
+		if got := values(q.Reachable(gs.Get(c.start))); got != c.expect {
+			t.Fatalf("%s from %s: expected %s got %s", c.expr, c.start, c.expect, got)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package ds
+
+import (
+	"container/heap"
+	"sync/atomic"
+
+	"github.com/influx6/sequence"
+)
+
+//priorityItem is a frontier entry of a priority transversal
+type priorityItem struct {
+	node, parent Nodes
+	socket       *Socket
+	depth        int
+	cost         float64
+	rank         float64
+	seq          int64
+}
+
+//priorityQueue provides a min-heap of frontier entries, entries of equal rank leave in the order they were added
+type priorityQueue []*priorityItem
+
+func (q priorityQueue) Len() int { return len(q) }
+
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].rank == q[j].rank {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].rank < q[j].rank
+}
+
+func (q priorityQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue) Push(x interface{}) {
+	*q = append(*q, x.(*priorityItem))
+}
+
+func (q *priorityQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return item
+}
+
+//PriorityFirst returns a search provider for the BestFirst and Weighted orders
+func PriorityFirst(directive *TransversalDirective) (t *Transversor) {
+	t = MakeTransversor(directive)
+
+	var queue priorityQueue
+	var seq int64
+
+	t.reset = func() {
+		queue = nil
+		seq = 0
+	}
+
+	push := func(n, parent Nodes, soc *Socket, depth int, cost float64) {
+		var rank float64
+
+		switch {
+		case t.directive.Order == Weighted:
+			rank = cost
+			if t.directive.Priority != nil {
+				rank += t.directive.Priority(n, soc, depth)
+			}
+		case t.directive.Priority != nil:
+			rank = t.directive.Priority(n, soc, depth)
+		default:
+			rank = float64(depth)
+		}
+
+		heap.Push(&queue, &priorityItem{
+			node:   n,
+			parent: parent,
+			socket: soc,
+			depth:  depth,
+			cost:   cost,
+			rank:   rank,
+			seq:    seq,
+		})
+		seq++
+	}
+
+	t.next = func() error {
+		for {
+			if err := t.interrupted(); err != nil {
+				return err
+			}
+
+			if queue.Len() <= 0 {
+				roots := t.nextRoots()
+
+				if len(roots) == 0 {
+					t.current = nil
+					return sequence.ErrBADINDEX
+				}
+
+				atomic.StoreInt64(&t.started, 1)
+
+				for _, root := range roots {
+					push(root, nil, nil, 0, 0)
+				}
+			}
+
+			item := heap.Pop(&queue).(*priorityItem)
+			node := item.node
+
+			if t.directive.Depth != -1 && item.depth >= t.directive.Depth {
+				continue
+			}
+
+			if !t.directive.Revisits(node, t.visited.Valid(node)) {
+				continue
+			}
+
+			atomic.StoreInt64(&t.walkdepth, int64(item.depth))
+
+			if item.socket != nil {
+				if t.directive.Heuristic(node, item.socket) != nil {
+					continue
+				}
+
+				t.link(node, item.parent, item.socket)
+			}
+
+			t.visited.Add(node)
+
+			itr := t.nodeCache(node).Itr
+
+			for itr.Next() == nil {
+				soc, ok := itr.Value().(*Socket)
+
+				if !ok {
+					t.current = nil
+					return ErrBadEdgeType
+				}
+
+				co := peer(soc, node)
+
+				if !t.directive.Revisits(co, t.visited.Valid(co)) {
+					continue
+				}
+
+				push(co, node, soc, item.depth+1, item.cost+float64(soc.Weight))
+			}
+
+			t.current = node
+			return nil
+		}
+	}
+
+	return
+}
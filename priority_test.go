@@ -0,0 +1,148 @@
+package ds
+
+import (
+	"fmt"
+	"testing"
+)
+
+func collectOrder(t *testing.T, dir *TransversalDirective, start Nodes) (*GraphProc, string) {
+	var order []interface{}
+
+	proc, err := Search(func(n Nodes, _ *Socket, _ int) error {
+		order = append(order, n.Value())
+		return nil
+	}, dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proc.Use(start)
+
+	for proc.Next() == nil {
+	}
+
+	return proc, fmt.Sprint(order)
+}
+
+func TestWeightedOrder(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+	gs.Bind(1, 2, 5)
+	gs.Bind(1, 3, 1)
+	gs.Bind(3, 2, 1)
+	gs.Bind(2, 4, 1)
+
+	proc, order := collectOrder(t, WeightedDirective(nil, nil), gs.Get(1))
+
+	if order != "[1 3 2 4]" {
+		t.Fatalf("Unexpected weighted order %s", order)
+	}
+
+	if proc.Parent(gs.Get(2)).Value() != 3 {
+		t.Fatalf("Expected 2 to be reached through the cheaper 3 got %s", proc.Parent(gs.Get(2)))
+	}
+
+	if len(proc.PathTo(gs.Get(4))) != 3 {
+		t.Fatal("Expected cheapest path to 4 to take 3 sockets")
+	}
+}
+
+func TestBestFirstOrder(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+	gs.Bind(1, 2, 5)
+	gs.Bind(1, 3, 1)
+	gs.Bind(3, 2, 1)
+	gs.Bind(2, 4, 1)
+
+	byValue := func(n Nodes, _ *Socket, _ int) float64 {
+		return float64(n.Value().(int))
+	}
+
+	if _, order := collectOrder(t, BestFirstDirective(byValue, nil, nil), gs.Get(1)); order != "[1 2 3 4]" {
+		t.Fatalf("Unexpected best-first order %s", order)
+	}
+
+	byLargest := func(n Nodes, _ *Socket, _ int) float64 {
+		return -float64(n.Value().(int))
+	}
+
+	if _, order := collectOrder(t, BestFirstDirective(byLargest, nil, nil), gs.Get(1)); order != "[1 3 2 4]" {
+		t.Fatalf("Unexpected best-first order %s", order)
+	}
+
+	dir := BestFirstDirective(byValue, nil, nil)
+	dir.Depth = 2
+
+	if _, order := collectOrder(t, dir, gs.Get(1)); order != "[1 2 3]" {
+		t.Fatalf("Unexpected depth limited order %s", order)
+	}
+}
+
+func TestPriorityFilter(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+	gs.Bind(1, 2, 5)
+	gs.Bind(1, 3, 1)
+	gs.Bind(3, 2, 1)
+	gs.Bind(2, 4, 1)
+
+	builder, err := Filter(WeightedDirective(nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := builder.Evaluator(func(n Nodes, soc *Socket, depth int) bool {
+		return n.Value() != 3
+	}).Transverse(gs.Get(1))
+
+	for filter.Next() == nil {
+	}
+
+	if len(filter.Nodes()) != 3 {
+		t.Fatalf("Expected filter to skip 3 and keep 1, 2, 4 got %d nodes", len(filter.Nodes()))
+	}
+
+	if filter.Path()[1].Socket.From.Value() != 1 {
+		t.Fatal("Expected 2 to be reached directly once 3 is filtered out")
+	}
+}
+
+func TestPriorityFilterSockets(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+
+	for _, to := range []int{2, 3} {
+		so, _ := gs.Bind(1, to, to)
+		so.Attrs.Add("x")
+	}
+
+	gs.Bind(1, 4, 1)
+
+	byValue := func(n Nodes, _ *Socket, _ int) float64 {
+		return float64(n.Value().(int))
+	}
+
+	for _, dir := range []*TransversalDirective{WeightedDirective(nil, nil), BestFirstDirective(byValue, nil, nil)} {
+		builder, err := Filter(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		filter := builder.Evaluator(EdgeAttr("x")).Transverse(gs.Get(1))
+
+		for filter.Next() == nil {
+		}
+
+		var values []interface{}
+		for _, n := range filter.Nodes() {
+			values = append(values, n.Value())
+		}
+
+		if fmt.Sprint(values) != "[1 2 3]" {
+			t.Fatalf("%s: expected the root and its x sockets [1 2 3] got %v", dir.Order, values)
+		}
+	}
+}
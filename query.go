@@ -0,0 +1,358 @@
+package ds
+
+import "reflect"
+
+//queryTraverser is a position of a query with the sockets it took to get there
+type queryTraverser struct {
+	node Nodes
+	path []*Socket
+}
+
+//socket returns the socket the traverser last moved across, nil for a start node
+func (q *queryTraverser) socket() *Socket {
+	if len(q.path) == 0 {
+		return nil
+	}
+	return q.path[len(q.path)-1]
+}
+
+//move returns a traverser moved across the socket to the node
+func (q *queryTraverser) move(n Nodes, sc ...*Socket) *queryTraverser {
+	path := make([]*Socket, len(q.path), len(q.path)+len(sc))
+	copy(path, q.path)
+
+	return &queryTraverser{
+		node: n,
+		path: append(path, sc...),
+	}
+}
+
+//queryStep provides a stage of a query over its traversers
+type queryStep func([]*queryTraverser) ([]*queryTraverser, error)
+
+//GraphQuery provides a fluent query over a graph, steps are run in order once a terminal such as Values or Nodes is called
+type GraphQuery struct {
+	g        Graphs
+	steps    []queryStep
+	err      error
+	incoming map[Nodes][]*Socket
+}
+
+//Query returns a GraphQuery over the graph
+func Query(g Graphs) *GraphQuery {
+	return &GraphQuery{g: g}
+}
+
+//step adds a stage to the query
+func (q *GraphQuery) step(s queryStep) *GraphQuery {
+	q.steps = append(q.steps, s)
+	return q
+}
+
+//V starts the query at the nodes with the giving values, or at every node of the graph when none are given
+func (q *GraphQuery) V(values ...interface{}) *GraphQuery {
+	return q.step(func([]*queryTraverser) ([]*queryTraverser, error) {
+		var ts []*queryTraverser
+
+		if len(values) == 0 {
+			for _, n := range q.g.nodeSet().AllNodes() {
+				ts = append(ts, &queryTraverser{node: n})
+			}
+			return ts, nil
+		}
+
+		for _, v := range values {
+			if n := q.g.Get(v); n != nil {
+				ts = append(ts, &queryTraverser{node: n})
+			}
+		}
+
+		return ts, nil
+	})
+}
+
+//hop moves every traverser to the neighbours of its node in the direction reached by sockets carrying any of the attributes, or by any socket when none are given
+func (q *GraphQuery) hop(d Direction, attrs []string) *GraphQuery {
+	return q.step(func(ts []*queryTraverser) ([]*queryTraverser, error) {
+		if len(ts) == 0 {
+			return nil, nil
+		}
+
+		dir := BFPreOrderDirective(nil, nil)
+		dir.Direction = d
+
+		builder, err := Filter(dir)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if d != Out {
+			if q.incoming == nil {
+				q.incoming = incomingSockets(q.g)
+			}
+			builder.filter.proc.trans.shareIncoming(q.incoming)
+		}
+
+		var start Nodes
+
+		filter := builder.Evaluator(func(n Nodes, sc *Socket, _ int) bool {
+			if sc == nil {
+				return true
+			}
+			return peer(sc, n) == start && socketHasAny(sc, attrs)
+		})
+
+		var next []*queryTraverser
+		var hops *GraphFilter
+
+		for _, t := range ts {
+			start = t.node
+
+			if hops == nil {
+				hops = filter.Transverse(start)
+			} else {
+				hops.Transverse(start)
+			}
+
+			for hops.Next() == nil {
+			}
+
+			for _, fn := range hops.Path() {
+				if fn.Socket != nil {
+					next = append(next, t.move(fn.Node, fn.Socket))
+				}
+			}
+		}
+
+		return next, nil
+	})
+}
+
+//socketHasAny returns true if the socket carries any of the attributes or none are given
+func socketHasAny(sc *Socket, attrs []string) bool {
+	if len(attrs) == 0 {
+		return true
+	}
+
+	for _, attr := range attrs {
+		if sc.Attrs != nil && sc.Attrs.Has(attr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Out moves the query along outgoing sockets carrying any of the attributes, or all outgoing sockets when none are given
+func (q *GraphQuery) Out(attrs ...string) *GraphQuery {
+	return q.hop(Out, attrs)
+}
+
+//In moves the query along incoming sockets carrying any of the attributes, or all incoming sockets when none are given
+func (q *GraphQuery) In(attrs ...string) *GraphQuery {
+	return q.hop(In, attrs)
+}
+
+//Both moves the query along sockets of either direction carrying any of the attributes, or all of them when none are given
+func (q *GraphQuery) Both(attrs ...string) *GraphQuery {
+	return q.hop(Both, attrs)
+}
+
+//Walk replaces every position of the query with the nodes reached by a transversal of the directive from it, the start node included when the order visits it
+func (q *GraphQuery) Walk(dir *TransversalDirective) *GraphQuery {
+	return q.step(func(ts []*queryTraverser) ([]*queryTraverser, error) {
+		var next []*queryTraverser
+
+		for _, t := range ts {
+			trans, err := CreateGraphTransversor(dir)
+
+			if err != nil {
+				return nil, err
+			}
+
+			trans.Use(t.node)
+
+			for trans.Next() == nil {
+				n := trans.Node()
+				next = append(next, t.move(n, trans.PathTo(n)...))
+			}
+		}
+
+		return next, nil
+	})
+}
+
+//filter keeps the traversers that pass the check
+func (q *GraphQuery) filter(fx func(*queryTraverser) bool) *GraphQuery {
+	return q.step(func(ts []*queryTraverser) ([]*queryTraverser, error) {
+		var kept []*queryTraverser
+
+		for _, t := range ts {
+			if fx(t) {
+				kept = append(kept, t)
+			}
+		}
+
+		return kept, nil
+	})
+}
+
+//Has keeps the positions whose last socket holds the key with a value equal to v
+func (q *GraphQuery) Has(key string, v interface{}) *GraphQuery {
+	return q.filter(func(t *queryTraverser) bool {
+		sc := t.socket()
+		if sc == nil || !sc.Has(key) {
+			return false
+		}
+		return Equals.Compare(sc.Get(key), v)
+	})
+}
+
+//HasLabel keeps the positions whose node carries any of the labels
+func (q *GraphQuery) HasLabel(labels ...string) *GraphQuery {
+	return q.filter(func(t *queryTraverser) bool {
+		return nodeHasAny(t.node, labels)
+	})
+}
+
+//HasProperty keeps the positions whose node holds the property with a value equal to v
+func (q *GraphQuery) HasProperty(key string, v interface{}) *GraphQuery {
+	return q.filter(func(t *queryTraverser) bool {
+		props := t.node.Properties()
+		if !props.Has(key) {
+			return false
+		}
+		return Equals.Compare(props.Get(key), v)
+	})
+}
+
+//HasValue keeps the positions whose node holds any of the values
+func (q *GraphQuery) HasValue(values ...interface{}) *GraphQuery {
+	return q.filter(func(t *queryTraverser) bool {
+		for _, v := range values {
+			if reflect.DeepEqual(t.node.Value(), v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+//Where keeps the positions passing the evaluator, which receives the node, its last socket and the length of its path
+func (q *GraphQuery) Where(eval NodeEval) *GraphQuery {
+	return q.filter(func(t *queryTraverser) bool {
+		return eval(t.node, t.socket(), len(t.path))
+	})
+}
+
+//Dedup keeps the first position reaching each node
+func (q *GraphQuery) Dedup() *GraphQuery {
+	return q.step(func(ts []*queryTraverser) ([]*queryTraverser, error) {
+		seen := VisitMaps()
+
+		var kept []*queryTraverser
+
+		for _, t := range ts {
+			if seen[t.node] {
+				continue
+			}
+			seen[t.node] = true
+			kept = append(kept, t)
+		}
+
+		return kept, nil
+	})
+}
+
+//Limit keeps at most the first n positions
+func (q *GraphQuery) Limit(n int) *GraphQuery {
+	return q.step(func(ts []*queryTraverser) ([]*queryTraverser, error) {
+		if n >= 0 && len(ts) > n {
+			ts = ts[:n]
+		}
+		return ts, nil
+	})
+}
+
+//run runs the steps of the query, recording the first error
+func (q *GraphQuery) run() []*queryTraverser {
+	var ts []*queryTraverser
+
+	q.err = nil
+	q.incoming = nil
+
+	if q.g == nil {
+		q.err = ErrBadGraph
+		return nil
+	}
+
+	for _, s := range q.steps {
+		next, err := s(ts)
+
+		if err != nil {
+			q.err = err
+			return nil
+		}
+
+		ts = next
+	}
+
+	return ts
+}
+
+//Err returns the error of the last run of the query if any
+func (q *GraphQuery) Err() error {
+	return q.err
+}
+
+//Nodes runs the query and returns the node of each position
+func (q *GraphQuery) Nodes() []Nodes {
+	var nodes []Nodes
+
+	for _, t := range q.run() {
+		nodes = append(nodes, t.node)
+	}
+
+	return nodes
+}
+
+//Values runs the query and returns the value of the node of each position
+func (q *GraphQuery) Values() []interface{} {
+	var values []interface{}
+
+	for _, t := range q.run() {
+		values = append(values, t.node.Value())
+	}
+
+	return values
+}
+
+//Sockets runs the query and returns the last socket of each position which has moved
+func (q *GraphQuery) Sockets() []*Socket {
+	var socks []*Socket
+
+	for _, t := range q.run() {
+		if sc := t.socket(); sc != nil {
+			socks = append(socks, sc)
+		}
+	}
+
+	return socks
+}
+
+//Paths runs the query and returns the sockets taken by each position from its start node
+func (q *GraphQuery) Paths() [][]*Socket {
+	var paths [][]*Socket
+
+	for _, t := range q.run() {
+		paths = append(paths, t.path)
+	}
+
+	return paths
+}
+
+//Count runs the query and returns the number of positions
+func (q *GraphQuery) Count() int {
+	return len(q.run())
+}
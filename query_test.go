@@ -0,0 +1,146 @@
+package ds
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQuerySteps(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("alice", "bob", "carol", "dave", "erin")
+
+	connect := func(a, b, attr string, since int) {
+		so := gs.Get(a).Connect(gs.Get(b), 1)
+		so.Attrs.Add(attr)
+		so.Set("since", since)
+	}
+
+	connect("alice", "bob", "knows", 2010)
+	connect("alice", "carol", "knows", 2015)
+	connect("alice", "dave", "works", 2018)
+	connect("bob", "erin", "knows", 2012)
+	connect("carol", "erin", "knows", 2016)
+	connect("dave", "carol", "works", 2019)
+
+	cases := []struct {
+		q      *GraphQuery
+		expect string
+	}{
+		{Query(gs).V("alice").Out(), "[bob carol dave]"},
+		{Query(gs).V("alice").Out("knows"), "[bob carol]"},
+		{Query(gs).V("alice").Out("knows").Out("knows"), "[erin erin]"},
+		{Query(gs).V("alice").Out("knows").Out("knows").Dedup(), "[erin]"},
+		{Query(gs).V("erin").In(), "[bob carol]"},
+		{Query(gs).V("carol").Both(), "[erin alice dave]"},
+		{Query(gs).V("alice").Out().Has("since", 2015), "[carol]"},
+		{Query(gs).V("alice").Out().Where(EdgeKeyCompare("since", GreaterThan, 2012)), "[carol dave]"},
+		{Query(gs).V("alice").Out().Limit(2), "[bob carol]"},
+		{Query(gs).V().HasValue("bob", "dave"), "[bob dave]"},
+		{Query(gs).V("alice").Walk(BFPreOrderDirective(nil, nil)).Limit(3), "[alice bob carol]"},
+		{Query(gs).V("nobody").Out(), "[]"},
+	}
+
+	for i, c := range cases {
+		if got := fmt.Sprint(c.q.Values()); got != c.expect {
+			t.Fatalf("Case %d: expected %s got %s", i, c.expect, got)
+		}
+	}
+}
+
+func TestQueryTerminals(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("alice", "bob", "carol", "dave", "erin")
+
+	for _, e := range [][3]string{{"alice", "bob", "knows"}, {"bob", "erin", "knows"}, {"alice", "dave", "works"}, {"dave", "carol", "works"}} {
+		so, _ := gs.Bind(e[0], e[1], 1)
+		so.Attrs.Add(e[2])
+	}
+
+	q := Query(gs).V("alice").Out("works").Out("works")
+
+	if q.Count() != 1 || q.Nodes()[0].Value() != "carol" {
+		t.Fatal("Expected alice to reach carol through works")
+	}
+
+	paths := q.Paths()
+
+	if len(paths) != 1 || len(paths[0]) != 2 || paths[0][0].To.Value() != "dave" {
+		t.Fatal("Unexpected path through dave")
+	}
+
+	socks := q.Sockets()
+
+	if len(socks) != 1 || socks[0].From.Value() != "dave" {
+		t.Fatal("Expected the last socket to leave dave")
+	}
+
+	walk := Query(gs).V("alice").Walk(BFPreOrderDirective(nil, nil)).HasValue("erin").Paths()
+
+	if len(walk) != 1 || len(walk[0]) != 2 {
+		t.Fatal("Expected walk to keep the transversal path to erin")
+	}
+
+	bad := Query(gs).V("alice").Walk(&TransversalDirective{Order: "sideways"})
+
+	if bad.Values() != nil || bad.Err() == nil {
+		t.Fatal("Expected an unknown order to fail the query")
+	}
+
+	if Query(nil).V().Values() != nil {
+		t.Fatal("Expected a query without a graph to return nothing")
+	}
+}
+
+func TestQueryProperties(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("alice", "bob", "carol", "dave")
+	gs.Bind("alice", "bob", 1)
+	gs.Bind("alice", "carol", 1)
+	gs.Bind("alice", "dave", 1)
+
+	for _, name := range []string{"alice", "bob", "carol"} {
+		gs.Get(name).Labels().Add("person")
+	}
+
+	gs.Get("dave").Labels().Add("robot")
+	gs.Get("bob").Properties().Set("team", "red")
+	gs.Get("carol").Properties().Set("team", "blue")
+
+	cases := []struct {
+		q      *GraphQuery
+		expect string
+	}{
+		{Query(gs).V().HasLabel("person"), "[alice bob carol]"},
+		{Query(gs).V().HasLabel("robot", "alien"), "[dave]"},
+		{Query(gs).V("alice").Out().HasLabel("person").HasProperty("team", "blue"), "[carol]"},
+		{Query(gs).V().Where(NodeKeyCompare("team", GreaterThan, "blue")), "[bob]"},
+	}
+
+	for i, c := range cases {
+		if got := fmt.Sprint(c.q.Values()); got != c.expect {
+			t.Fatalf("Case %d: expected %s got %s", i, c.expect, got)
+		}
+	}
+}
+
+func TestQueryIncomingRuns(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("a", "b", "c")
+	gs.Bind("a", "c", 1)
+
+	q := Query(gs).V("c").In().In()
+
+	if got := fmt.Sprint(Query(gs).V("c").In().Values()); got != "[a]" {
+		t.Fatalf("Expected a to lead into c got %s", got)
+	}
+
+	if q.Count() != 0 {
+		t.Fatal("Expected nothing to lead into a")
+	}
+
+	gs.Bind("b", "a", 1)
+
+	if got := fmt.Sprint(q.Values()); got != "[b]" {
+		t.Fatalf("Expected a new run to see the new socket into a got %s", got)
+	}
+}
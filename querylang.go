@@ -0,0 +1,1003 @@
+package ds
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//QueryResult holds the columns and rows returned by a textual query, node columns hold Nodes, socket columns hold *Socket and key columns hold the key value
+type QueryResult struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+//String returns the result as a table of values
+func (r *QueryResult) String() string {
+	var out []string
+
+	out = append(out, strings.Join(r.Columns, " | "))
+
+	for _, row := range r.Rows {
+		cells := make([]string, len(row))
+
+		for i, cell := range row {
+			switch c := cell.(type) {
+			case Nodes:
+				cells[i] = fmt.Sprint(c.Value())
+			case *Socket:
+				cells[i] = fmt.Sprintf("%v->%v", c.From.Value(), c.To.Value())
+			default:
+				cells[i] = fmt.Sprint(c)
+			}
+		}
+
+		out = append(out, strings.Join(cells, " | "))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+//RunQuery parses and runs a textual query against the graph
+func RunQuery(g Graphs, src string) (*QueryResult, error) {
+	plan, err := ParseQuery(src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.Execute(g)
+}
+
+//qtokenKind provides the kinds of query tokens
+type qtokenKind int
+
+const (
+	qEOF qtokenKind = iota
+	qIdent
+	qString
+	qNumber
+	qPunct
+)
+
+//qtoken is a lexed token of a query
+type qtoken struct {
+	kind qtokenKind
+	text string
+	pos  int
+}
+
+//lexQuery splits a query into tokens
+func lexQuery(src string) ([]qtoken, error) {
+	var toks []qtoken
+
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			toks = append(toks, qtoken{qIdent, string(runes[start:i]), start})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			toks = append(toks, qtoken{qNumber, string(runes[start:i]), start})
+		case r == '"' || r == '\'':
+			start := i
+			var text []rune
+
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("Query syntax error at %d: unterminated string", start)
+				}
+
+				c := runes[i]
+
+				if c == r {
+					i++
+					break
+				}
+
+				if c == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						c = '\n'
+					case 't':
+						c = '\t'
+					default:
+						c = runes[i]
+					}
+				}
+
+				text = append(text, c)
+			}
+
+			toks = append(toks, qtoken{qString, string(text), start})
+		default:
+			if i+1 < len(runes) {
+				switch pair := string(runes[i : i+2]); pair {
+				case "<=", ">=", "!=", "<>", "==":
+					toks = append(toks, qtoken{qPunct, pair, i})
+					i += 2
+					continue
+				}
+			}
+
+			if !strings.ContainsRune("()[]{}:,.|-<>=*", r) {
+				return nil, fmt.Errorf("Query syntax error at %d: unexpected %q", i, r)
+			}
+
+			toks = append(toks, qtoken{qPunct, string(r), i})
+			i++
+		}
+	}
+
+	return append(toks, qtoken{kind: qEOF, pos: len(runes)}), nil
+}
+
+//patternEdge is a socket step of a query pattern
+type patternEdge struct {
+	variable  string
+	direction Direction
+	attrs     []string
+	conds     []qcompare
+}
+
+//patternNode holds the labels, any of which a node must carry, and the property comparisons of a node of a MATCH pattern
+type patternNode struct {
+	labels []string
+	conds  []qcompare
+}
+
+//qoperand is a side of a comparison, either a literal or a variable with an optional key
+type qoperand struct {
+	variable string
+	key      string
+	literal  interface{}
+	constant bool
+}
+
+//qbinding holds the nodes and sockets bound to the pattern positions of a match
+type qbinding struct {
+	plan  *QueryPlan
+	nodes []Nodes
+	edges []*Socket
+}
+
+//resolve returns the value of the operand for the binding, false if it has none
+func (o qoperand) resolve(b *qbinding) (interface{}, bool) {
+	if o.constant {
+		return o.literal, true
+	}
+
+	if ind, ok := b.plan.nodeVars[o.variable]; ok {
+		return nodeKey(b.nodes[ind], o.key)
+	}
+
+	if ind, ok := b.plan.edgeVars[o.variable]; ok {
+		return socketKey(b.edges[ind], o.key)
+	}
+
+	return nil, false
+}
+
+//nodeKey returns the value of the node for an empty or value key, else the property of the key
+func nodeKey(n Nodes, key string) (interface{}, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	if key == "" || key == "value" {
+		return n.Value(), true
+	}
+
+	props := n.Properties()
+
+	if !props.Has(key) {
+		return nil, false
+	}
+
+	return props.Get(key), true
+}
+
+//socketKey returns the weight or the collector value of the key of the socket
+func socketKey(sc *Socket, key string) (interface{}, bool) {
+	if sc == nil || key == "" {
+		return nil, false
+	}
+
+	if key == "weight" {
+		return sc.Weight, true
+	}
+
+	if !sc.Has(key) {
+		return nil, false
+	}
+
+	return sc.Get(key), true
+}
+
+//qexpr is a boolean expression of a WHERE clause
+type qexpr interface {
+	eval(*qbinding) bool
+}
+
+type qand struct{ left, right qexpr }
+
+func (e qand) eval(b *qbinding) bool { return e.left.eval(b) && e.right.eval(b) }
+
+type qor struct{ left, right qexpr }
+
+func (e qor) eval(b *qbinding) bool { return e.left.eval(b) || e.right.eval(b) }
+
+type qnot struct{ expr qexpr }
+
+func (e qnot) eval(b *qbinding) bool { return !e.expr.eval(b) }
+
+//qcompare compares two operands, an operand without a value fails the comparison
+type qcompare struct {
+	left, right qoperand
+	op          Comparison
+}
+
+func (e qcompare) eval(b *qbinding) bool {
+	l, ok := e.left.resolve(b)
+	if !ok {
+		return false
+	}
+
+	r, ok := e.right.resolve(b)
+	if !ok {
+		return false
+	}
+
+	return e.op.Compare(l, r)
+}
+
+//QueryPlan provides a parsed textual query ready to run against graphs
+type QueryPlan struct {
+	nodes    []string
+	filters  []patternNode
+	edges    []patternEdge
+	where    qexpr
+	returns  []qoperand
+	columns  []string
+	limit    int
+	nodeVars map[string]int
+	edgeVars map[string]int
+	anchors  map[int]interface{}
+}
+
+//queryParser provides a recursive descent parser over query tokens
+type queryParser struct {
+	toks []qtoken
+	at   int
+}
+
+func (p *queryParser) peek() qtoken {
+	return p.toks[p.at]
+}
+
+func (p *queryParser) next() qtoken {
+	tok := p.toks[p.at]
+	if tok.kind != qEOF {
+		p.at++
+	}
+	return tok
+}
+
+func (p *queryParser) fail(msg string, args ...interface{}) error {
+	return fmt.Errorf("Query syntax error at %d: %s", p.peek().pos, fmt.Sprintf(msg, args...))
+}
+
+//is returns true if the next token is the punctuation
+func (p *queryParser) is(punct string) bool {
+	tok := p.peek()
+	return tok.kind == qPunct && tok.text == punct
+}
+
+//accept consumes the punctuation if it is next
+func (p *queryParser) accept(punct string) bool {
+	if p.is(punct) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) expect(punct string) error {
+	if !p.accept(punct) {
+		return p.fail("expected %q", punct)
+	}
+	return nil
+}
+
+//isKeyword returns true if the next token is the keyword, keywords are not case sensitive
+func (p *queryParser) isKeyword(kw string) bool {
+	tok := p.peek()
+	return tok.kind == qIdent && strings.EqualFold(tok.text, kw)
+}
+
+func (p *queryParser) ident() (string, error) {
+	tok := p.peek()
+	if tok.kind != qIdent {
+		return "", p.fail("expected a name")
+	}
+	p.next()
+	return tok.text, nil
+}
+
+//ParseQuery parses a textual MATCH query into a plan, WHERE and LIMIT are optional
+func ParseQuery(src string) (*QueryPlan, error) {
+	toks, err := lexQuery(src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{toks: toks}
+	plan := &QueryPlan{
+		limit:    -1,
+		nodeVars: make(map[string]int),
+		edgeVars: make(map[string]int),
+		anchors:  make(map[int]interface{}),
+	}
+
+	if !p.isKeyword("match") {
+		return nil, p.fail("expected MATCH")
+	}
+	p.next()
+
+	if err := p.parsePattern(plan); err != nil {
+		return nil, err
+	}
+
+	if p.isKeyword("where") {
+		p.next()
+
+		if plan.where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.isKeyword("return") {
+		return nil, p.fail("expected RETURN")
+	}
+	p.next()
+
+	if err := p.parseReturns(plan); err != nil {
+		return nil, err
+	}
+
+	if p.isKeyword("limit") {
+		p.next()
+
+		tok := p.next()
+		limit, err := strconv.Atoi(tok.text)
+
+		if tok.kind != qNumber || err != nil {
+			return nil, fmt.Errorf("Query syntax error at %d: expected a whole number", tok.pos)
+		}
+
+		plan.limit = limit
+	}
+
+	if p.peek().kind != qEOF {
+		return nil, p.fail("unexpected %q", p.peek().text)
+	}
+
+	if err := plan.check(plan.where); err != nil {
+		return nil, err
+	}
+
+	for _, o := range plan.returns {
+		if err := plan.checkOperand(o); err != nil {
+			return nil, err
+		}
+	}
+
+	plan.anchor(plan.where)
+
+	return plan, nil
+}
+
+func (p *queryParser) parsePattern(plan *QueryPlan) error {
+	if err := p.parseNode(plan); err != nil {
+		return err
+	}
+
+	for p.is("-") || p.is("<") {
+		if err := p.parseEdge(plan); err != nil {
+			return err
+		}
+
+		if err := p.parseNode(plan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *queryParser) parseNode(plan *QueryPlan) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+
+	var name string
+	var filter patternNode
+
+	if p.peek().kind == qIdent {
+		name, _ = p.ident()
+
+		if _, ok := plan.edgeVars[name]; ok {
+			return p.fail("%s is already a socket", name)
+		}
+
+		if _, ok := plan.nodeVars[name]; !ok {
+			plan.nodeVars[name] = len(plan.nodes)
+		}
+	}
+
+	var err error
+
+	if filter.labels, filter.conds, err = p.parseFilter(); err != nil {
+		return err
+	}
+
+	plan.nodes = append(plan.nodes, name)
+	plan.filters = append(plan.filters, filter)
+
+	return p.expect(")")
+}
+
+//parseFilter parses the optional names after a colon and the optional braces of key comparisons of a node or socket pattern
+func (p *queryParser) parseFilter() ([]string, []qcompare, error) {
+	var names []string
+	var conds []qcompare
+
+	if p.accept(":") {
+		for {
+			name, err := p.ident()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			names = append(names, name)
+
+			if !p.accept("|") {
+				break
+			}
+		}
+	}
+
+	if p.accept("{") {
+		for !p.is("}") {
+			key, err := p.ident()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			op := Equals
+
+			if !p.accept(":") {
+				if op, err = p.parseComparison(); err != nil {
+					return nil, nil, err
+				}
+			}
+
+			val, err := p.parseLiteral()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			conds = append(conds, qcompare{
+				left:  qoperand{key: key},
+				op:    op,
+				right: val,
+			})
+
+			if !p.accept(",") {
+				break
+			}
+		}
+
+		if err := p.expect("}"); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return names, conds, nil
+}
+
+func (p *queryParser) parseEdge(plan *QueryPlan) error {
+	var edge patternEdge
+
+	left := p.accept("<")
+
+	if err := p.expect("-"); err != nil {
+		return err
+	}
+
+	if p.accept("[") {
+		if p.peek().kind == qIdent {
+			edge.variable, _ = p.ident()
+
+			if _, ok := plan.nodeVars[edge.variable]; ok {
+				return p.fail("%s is already a node", edge.variable)
+			}
+
+			if _, ok := plan.edgeVars[edge.variable]; ok {
+				return p.fail("socket %s is bound twice", edge.variable)
+			}
+
+			plan.edgeVars[edge.variable] = len(plan.edges)
+		}
+
+		var err error
+
+		if edge.attrs, edge.conds, err = p.parseFilter(); err != nil {
+			return err
+		}
+
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	}
+
+	if err := p.expect("-"); err != nil {
+		return err
+	}
+
+	right := p.accept(">")
+
+	switch {
+	case left && right:
+		return p.fail("a socket can not point both ways")
+	case left:
+		edge.direction = In
+	case right:
+		edge.direction = Out
+	default:
+		edge.direction = Both
+	}
+
+	plan.edges = append(plan.edges, edge)
+	return nil
+}
+
+func (p *queryParser) parseOr() (qexpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = qor{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (qexpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+		p.next()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = qand{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (qexpr, error) {
+	if p.isKeyword("not") {
+		p.next()
+
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return qnot{expr}, nil
+	}
+
+	if p.accept("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return expr, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return qcompare{left: left, op: op, right: right}, nil
+}
+
+func (p *queryParser) parseComparison() (Comparison, error) {
+	tok := p.peek()
+
+	if tok.kind == qPunct {
+		switch tok.text {
+		case "=", "==":
+			p.next()
+			return Equals, nil
+		case "!=", "<>":
+			p.next()
+			return NotEquals, nil
+		case "<", "<=", ">", ">=":
+			p.next()
+			return Comparison(tok.text), nil
+		}
+	}
+
+	return "", p.fail("expected a comparison")
+}
+
+//parseOperand parses a variable, a variable key or a literal
+func (p *queryParser) parseOperand() (qoperand, error) {
+	tok := p.peek()
+
+	if tok.kind == qIdent && !strings.EqualFold(tok.text, "true") && !strings.EqualFold(tok.text, "false") {
+		p.next()
+
+		o := qoperand{variable: tok.text}
+
+		if p.accept(".") {
+			key, err := p.ident()
+			if err != nil {
+				return o, err
+			}
+			o.key = key
+		}
+
+		return o, nil
+	}
+
+	return p.parseLiteral()
+}
+
+//parseLiteral parses a string, number or boolean
+func (p *queryParser) parseLiteral() (qoperand, error) {
+	negative := p.accept("-")
+	tok := p.peek()
+
+	switch {
+	case tok.kind == qString && !negative:
+		p.next()
+		return qoperand{literal: tok.text, constant: true}, nil
+	case tok.kind == qNumber:
+		p.next()
+
+		text := tok.text
+		if negative {
+			text = "-" + text
+		}
+
+		if n, err := strconv.Atoi(text); err == nil {
+			return qoperand{literal: n, constant: true}, nil
+		}
+
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return qoperand{}, fmt.Errorf("Query syntax error at %d: bad number %s", tok.pos, tok.text)
+		}
+
+		return qoperand{literal: f, constant: true}, nil
+	case tok.kind == qIdent && !negative && (strings.EqualFold(tok.text, "true") || strings.EqualFold(tok.text, "false")):
+		p.next()
+		return qoperand{literal: strings.EqualFold(tok.text, "true"), constant: true}, nil
+	}
+
+	return qoperand{}, p.fail("expected a value")
+}
+
+func (p *queryParser) parseReturns(plan *QueryPlan) error {
+	for {
+		start := p.peek().pos
+
+		o, err := p.parseOperand()
+		if err != nil {
+			return err
+		}
+
+		if o.constant {
+			return fmt.Errorf("Query syntax error at %d: RETURN expects a variable", start)
+		}
+
+		column := o.variable
+		if o.key != "" {
+			column += "." + o.key
+		}
+
+		plan.returns = append(plan.returns, o)
+		plan.columns = append(plan.columns, column)
+
+		if !p.accept(",") {
+			return nil
+		}
+	}
+}
+
+//checkOperand returns an error if the operand names a variable missing from the pattern
+func (plan *QueryPlan) checkOperand(o qoperand) error {
+	if o.constant {
+		return nil
+	}
+
+	if _, ok := plan.nodeVars[o.variable]; ok {
+		return nil
+	}
+
+	if _, ok := plan.edgeVars[o.variable]; ok {
+		return nil
+	}
+
+	return fmt.Errorf("Query error: unknown variable %s", o.variable)
+}
+
+//check returns an error if the expression names a variable missing from the pattern
+func (plan *QueryPlan) check(e qexpr) error {
+	switch ex := e.(type) {
+	case qand:
+		if err := plan.check(ex.left); err != nil {
+			return err
+		}
+		return plan.check(ex.right)
+	case qor:
+		if err := plan.check(ex.left); err != nil {
+			return err
+		}
+		return plan.check(ex.right)
+	case qnot:
+		return plan.check(ex.expr)
+	case qcompare:
+		if err := plan.checkOperand(ex.left); err != nil {
+			return err
+		}
+		return plan.checkOperand(ex.right)
+	}
+	return nil
+}
+
+//anchor records the node variables the WHERE clause fixes to a value through its top level AND comparisons, matching starts from those nodes
+func (plan *QueryPlan) anchor(e qexpr) {
+	switch ex := e.(type) {
+	case qand:
+		plan.anchor(ex.left)
+		plan.anchor(ex.right)
+	case qcompare:
+		if ex.op != Equals {
+			return
+		}
+
+		v, lit := ex.left, ex.right
+		if v.constant {
+			v, lit = lit, v
+		}
+
+		if v.constant || !lit.constant || (v.key != "" && v.key != "value") {
+			return
+		}
+
+		if ind, ok := plan.nodeVars[v.variable]; ok {
+			plan.anchors[ind] = lit.literal
+		}
+	}
+}
+
+//Columns returns the column names of the results of the plan
+func (plan *QueryPlan) Columns() []string {
+	return plan.columns
+}
+
+//Execute runs the plan against the graph
+func (plan *QueryPlan) Execute(g Graphs) (*QueryResult, error) {
+	if g == nil {
+		return nil, ErrBadGraph
+	}
+
+	result := &QueryResult{Columns: plan.columns}
+
+	var incoming map[Nodes][]*Socket
+
+	for _, e := range plan.edges {
+		if e.direction != Out {
+			incoming = incomingSockets(g)
+			break
+		}
+	}
+
+	b := &qbinding{
+		plan:  plan,
+		nodes: make([]Nodes, len(plan.nodes)),
+		edges: make([]*Socket, len(plan.edges)),
+	}
+
+	//bind places the node at the position, failing if it breaks its pattern filter, a repeated variable or an anchor
+	bind := func(i int, n Nodes) bool {
+		filter := plan.filters[i]
+
+		if len(filter.labels) > 0 && !nodeHasAny(n, filter.labels) {
+			return false
+		}
+
+		for _, cond := range filter.conds {
+			v, ok := nodeKey(n, cond.left.key)
+			if !ok || !cond.op.Compare(v, cond.right.literal) {
+				return false
+			}
+		}
+
+		if name := plan.nodes[i]; name != "" {
+			first := plan.nodeVars[name]
+
+			if first < i && b.nodes[first] != n {
+				return false
+			}
+
+			if v, ok := plan.anchors[first]; ok && !Equals.Compare(n.Value(), v) {
+				return false
+			}
+		}
+
+		b.nodes[i] = n
+		return true
+	}
+
+	var extend func(i int) bool
+
+	extend = func(i int) bool {
+		if i == len(plan.edges) {
+			if plan.where != nil && !plan.where.eval(b) {
+				return true
+			}
+
+			row := make([]interface{}, len(plan.returns))
+
+			for j, o := range plan.returns {
+				switch {
+				case o.key != "":
+					row[j], _ = o.resolve(b)
+				case plan.isNode(o.variable):
+					row[j] = b.nodes[plan.nodeVars[o.variable]]
+				default:
+					row[j] = b.edges[plan.edgeVars[o.variable]]
+				}
+			}
+
+			result.Rows = append(result.Rows, row)
+
+			return plan.limit < 0 || len(result.Rows) < plan.limit
+		}
+
+		edge := plan.edges[i]
+		from := b.nodes[i]
+
+	sockets:
+		for _, sc := range directedSockets(from, edge.direction, incoming) {
+			if !socketHasAny(sc, edge.attrs) {
+				continue
+			}
+
+			for _, used := range b.edges[:i] {
+				if used == sc {
+					continue sockets
+				}
+			}
+
+			for _, cond := range edge.conds {
+				v, ok := socketKey(sc, cond.left.key)
+				if !ok || !cond.op.Compare(v, cond.right.literal) {
+					continue sockets
+				}
+			}
+
+			if !bind(i+1, peer(sc, from)) {
+				continue
+			}
+
+			b.edges[i] = sc
+
+			if !extend(i + 1) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	var starts []Nodes
+
+	if v, ok := plan.anchors[0]; ok && plan.nodes[0] != "" {
+		if n := g.Get(v); n != nil {
+			starts = append(starts, n)
+		}
+	}
+
+	if labels := plan.filters[0].labels; starts == nil && len(labels) == 1 {
+		starts = g.NodesByLabel(labels[0])
+	}
+
+	if starts == nil {
+		starts = g.nodeSet().AllNodes()
+	}
+
+	if plan.limit == 0 {
+		return result, nil
+	}
+
+	for _, n := range starts {
+		if !bind(0, n) {
+			continue
+		}
+
+		if !extend(0) {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+//isNode returns true if the variable names a node of the pattern
+func (plan *QueryPlan) isNode(name string) bool {
+	_, ok := plan.nodeVars[name]
+	return ok
+}
+
+//nodeHasAny returns true if the node carries any of the labels
+func nodeHasAny(n Nodes, labels []string) bool {
+	for _, label := range labels {
+		if n.Labels().Has(label) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package ds
+
+import (
+	"fmt"
+	"testing"
+)
+
+func rows(r *QueryResult) string {
+	var out []string
+
+	for _, row := range r.Rows {
+		var cells []interface{}
+		for _, cell := range row {
+			switch c := cell.(type) {
+			case Nodes:
+				cells = append(cells, c.Value())
+			case *Socket:
+				cells = append(cells, fmt.Sprintf("%v>%v", c.From.Value(), c.To.Value()))
+			default:
+				cells = append(cells, c)
+			}
+		}
+		for _, cell := range cells {
+			out = append(out, fmt.Sprint(cell))
+		}
+	}
+
+	return fmt.Sprint(out)
+}
+
+func TestQueryLanguage(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("api", "auth", "db", "cache", "queue")
+
+	connect := func(a, b string, w int, attr string) *Socket {
+		so := gs.Get(a).Connect(gs.Get(b), w)
+		so.Attrs.Add(attr)
+		return so
+	}
+
+	connect("api", "auth", 5, "depends").Set("since", 2019)
+	connect("api", "cache", 2, "depends").Set("since", 2021)
+	connect("auth", "db", 7, "depends").Set("since", 2018)
+	connect("api", "queue", 4, "publishes")
+	connect("queue", "db", 1, "depends")
+
+	cases := []struct {
+		src    string
+		expect string
+	}{
+		{`MATCH (a)-[:depends {weight>3}]->(b) WHERE a = "api" RETURN b`, "[auth]"},
+		{`match (a)-[:depends]->(b) where a = 'api' return b limit 1`, "[auth]"},
+		{`MATCH (a)-->(b) WHERE a = "api" AND NOT (b = "auth" OR b = "queue") RETURN b`, "[cache]"},
+		{`MATCH (a)<-[:depends]-(b) WHERE a = "db" RETURN b`, "[auth queue]"},
+		{`MATCH (a)-[r]-(b) WHERE a = "queue" RETURN b, r`, "[db queue>db api api>queue]"},
+		{`MATCH (a)-[r:depends {since >= 2019}]->(b) RETURN a, r.since, b`, "[api 2019 auth api 2021 cache]"},
+		{`MATCH (a)-[:depends]->()-[:depends]->(c) RETURN a, c`, "[api db]"},
+		{`MATCH (a)-[:publishes|depends]->(b)-->(c) WHERE c = "db" RETURN a, b`, "[api auth api queue]"},
+		{`MATCH (a)-[r]->(b) WHERE r.weight < 2 RETURN r.weight`, "[1]"},
+		{`MATCH (a)-[r {since: 2018}]->(b) RETURN a`, "[auth]"},
+		{`MATCH (a)-->(a) RETURN a`, "[]"},
+	}
+
+	for _, c := range cases {
+		res, err := RunQuery(gs, c.src)
+
+		if err != nil {
+			t.Fatalf("%s: %s", c.src, err)
+		}
+
+		if got := rows(res); got != c.expect {
+			t.Fatalf("%s: expected %s got %s", c.src, c.expect, got)
+		}
+	}
+}
+
+func TestQueryLanguageResult(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("auth", "db")
+
+	so, _ := gs.Bind("auth", "db", 7)
+	so.Attrs.Add("depends")
+	so.Set("since", 2018)
+
+	res, err := RunQuery(gs, `MATCH (a)-[r:depends]->(b) WHERE a = "auth" RETURN a, r, r.since`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(res.Columns) != "[a r r.since]" {
+		t.Fatalf("Unexpected columns %v", res.Columns)
+	}
+
+	if res.String() != "a | r | r.since\nauth | auth->db | 2018" {
+		t.Fatalf("Unexpected table %q", res.String())
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	src := `MATCH (a:service {tier: 1})-[r:attr|other {weight > 3, key: "v"}]->(b)<-[:attr]-(c)--(d)
+	WHERE a = "api" AND (r.key >= 2 OR NOT b.owner = "ops")
+	RETURN a, r, r.key, b, b.owner
+	LIMIT 10`
+
+	if _, err := ParseQuery(src); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseQuery(`MATCH (a) RETURN a`); err != nil {
+		t.Fatalf("Expected WHERE and LIMIT to be optional got %s", err)
+	}
+}
+
+func TestQueryLanguageErrors(t *testing.T) {
+	bad := []string{
+		``,
+		`MATCH (a) RETURN`,
+		`MATCH (a)-[:x]->(b RETURN b`,
+		`MATCH (a)<-[:x]->(b) RETURN b`,
+		`MATCH (a) WHERE b = 1 RETURN a`,
+		`MATCH (a) RETURN c`,
+		`MATCH (a)-[a]->(b) RETURN a`,
+		`MATCH (a) WHERE a = "x RETURN a`,
+		`MATCH (a) RETURN a LIMIT x`,
+		`MATCH (a) WHERE a ~ 2 RETURN a`,
+	}
+
+	for _, src := range bad {
+		if _, err := ParseQuery(src); err == nil {
+			t.Fatalf("Expected %q to fail", src)
+		}
+	}
+
+	if _, err := RunQuery(nil, `MATCH (a) RETURN a`); err != ErrBadGraph {
+		t.Fatalf("Expected ErrBadGraph got %v", err)
+	}
+}
+
+func TestQueryLanguageNodes(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("api", "auth", "db", "cache", "queue")
+
+	connect := func(a, b string, w int, attr string) *Socket {
+		so := gs.Get(a).Connect(gs.Get(b), w)
+		so.Attrs.Add(attr)
+		return so
+	}
+
+	connect("api", "auth", 5, "depends").Set("since", 2019)
+	connect("api", "cache", 2, "depends").Set("since", 2021)
+	connect("auth", "db", 7, "depends").Set("since", 2018)
+	connect("api", "queue", 4, "publishes")
+	connect("queue", "db", 1, "depends")
+
+	for _, name := range []string{"api", "auth"} {
+		gs.Get(name).Labels().Add("service")
+	}
+
+	gs.Get("db").Labels().Add("store")
+	gs.Get("cache").Labels().Add("store")
+	gs.Get("api").Properties().Set("tier", 1)
+	gs.Get("auth").Properties().Set("tier", 2)
+	gs.Get("db").Properties().Set("owner", "ops")
+	gs.Get("cache").Properties().Set("owner", "web")
+
+	cases := []struct {
+		src    string
+		expect string
+	}{
+		{`MATCH (a:service) RETURN a, a.tier`, "[api 1 auth 2]"},
+		{`MATCH (a:service {tier > 1})-->(b) RETURN a, b`, "[auth db]"},
+		{`MATCH (a)-->(b:store|queue) WHERE a.tier = 1 RETURN b, b.owner`, "[cache web]"},
+		{`MATCH (a)-[:depends]->(b {owner: "ops"}) RETURN a`, "[auth queue]"},
+		{`MATCH (a:service)-->(b) WHERE b.owner = "web" OR b = "queue" RETURN b`, "[cache queue]"},
+		{`MATCH (a:missing) RETURN a`, "[]"},
+	}
+
+	for _, c := range cases {
+		res, err := RunQuery(gs, c.src)
+
+		if err != nil {
+			t.Fatalf("%s: %s", c.src, err)
+		}
+
+		if got := rows(res); got != c.expect {
+			t.Fatalf("%s: expected %s got %s", c.src, c.expect, got)
+		}
+	}
+}
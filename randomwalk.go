@@ -0,0 +1,187 @@
+package ds
+
+import "math/rand"
+
+//RandomWalk returns a walk of up to length nodes from the start node, following sockets chosen uniformly
+func RandomWalk(r *rand.Rand, start Nodes, length int) []Nodes {
+	return walk(start, length, func(cur Nodes, _ []Nodes) Nodes {
+		socks := OutSockets(cur)
+		if len(socks) == 0 {
+			return nil
+		}
+		return socks[r.Intn(len(socks))].To
+	})
+}
+
+//WeightedRandomWalk returns a walk of up to length nodes from the start node, following sockets chosen by weight
+func WeightedRandomWalk(r *rand.Rand, start Nodes, length int) []Nodes {
+	return walk(start, length, func(cur Nodes, _ []Nodes) Nodes {
+		socks := OutSockets(cur)
+		weights := make([]float64, len(socks))
+
+		for i, sc := range socks {
+			if sc.Weight > 0 {
+				weights[i] = float64(sc.Weight)
+			}
+		}
+
+		if i := pickWeighted(r, weights); i >= 0 {
+			return socks[i].To
+		}
+		return nil
+	})
+}
+
+//RandomWalkWithRestart returns a walk of length nodes from the start node, jumping back to it with the restart probability
+func RandomWalkWithRestart(r *rand.Rand, start Nodes, length int, restart float64) []Nodes {
+	return walk(start, length, func(cur Nodes, _ []Nodes) Nodes {
+		if r.Float64() < restart {
+			return start
+		}
+
+		socks := OutSockets(cur)
+		if len(socks) == 0 {
+			return start
+		}
+		return socks[r.Intn(len(socks))].To
+	})
+}
+
+//Node2VecWalk returns a walk of up to length nodes from the start node, biased by the return parameter p and the in-out parameter q which must both be positive or nil is returned
+func Node2VecWalk(r *rand.Rand, start Nodes, length int, p, q float64) []Nodes {
+	if !(p > 0) || !(q > 0) {
+		return nil
+	}
+
+	return walk(start, length, func(cur Nodes, path []Nodes) Nodes {
+		socks := OutSockets(cur)
+
+		if len(socks) == 0 {
+			return nil
+		}
+
+		if len(path) < 2 {
+			return socks[r.Intn(len(socks))].To
+		}
+
+		prev := path[len(path)-2]
+		weights := make([]float64, len(socks))
+
+		for i, sc := range socks {
+			switch {
+			case sc.To == prev:
+				weights[i] = 1 / p
+			case prev.HasEdge(sc.To) || sc.To.HasEdge(prev):
+				weights[i] = 1
+			default:
+				weights[i] = 1 / q
+			}
+		}
+
+		if i := pickWeighted(r, weights); i >= 0 {
+			return socks[i].To
+		}
+		return nil
+	})
+}
+
+//walk builds a walk of up to length nodes, next returns the node after the current one given the walk so far or nil to stop
+func walk(start Nodes, length int, next func(Nodes, []Nodes) Nodes) []Nodes {
+	if start == nil || length <= 0 {
+		return nil
+	}
+
+	path := make([]Nodes, 1, length)
+	path[0] = start
+
+	for len(path) < length {
+		nx := next(path[len(path)-1], path)
+		if nx == nil {
+			break
+		}
+		path = append(path, nx)
+	}
+
+	return path
+}
+
+//pickWeighted returns an index chosen with a probability proportional to its weight, -1 if no weight is positive
+func pickWeighted(r *rand.Rand, weights []float64) int {
+	var total float64
+
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+
+	if total <= 0 {
+		return -1
+	}
+
+	target := r.Float64() * total
+
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+
+		if target < w {
+			return i
+		}
+
+		target -= w
+	}
+
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+//SampleNodes returns k distinct nodes of the graph chosen uniformly, or all of them in random order when the graph holds k or fewer
+func SampleNodes(r *rand.Rand, g Graphs, k int) []Nodes {
+	if g == nil || k <= 0 {
+		return nil
+	}
+
+	nodes := g.nodeSet().AllNodes()
+
+	if k > len(nodes) {
+		k = len(nodes)
+	}
+
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(nodes)-i)
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+
+	return nodes[:k]
+}
+
+//SampleEdges returns k distinct sockets of the graph chosen uniformly, or all of them in random order when the graph holds k or fewer
+func SampleEdges(r *rand.Rand, g Graphs, k int) []*Socket {
+	if g == nil || k <= 0 {
+		return nil
+	}
+
+	var socks []*Socket
+
+	g.nodeSet().EachNode(func(n Nodes) {
+		socks = append(socks, OutSockets(n)...)
+	})
+
+	if k > len(socks) {
+		k = len(socks)
+	}
+
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(socks)-i)
+		socks[i], socks[j] = socks[j], socks[i]
+	}
+
+	return socks[:k]
+}
@@ -0,0 +1,147 @@
+package ds
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func checkWalk(t *testing.T, path []Nodes) {
+	for i := 1; i < len(path); i++ {
+		if !path[i-1].HasEdge(path[i]) {
+			t.Fatalf("Walk steps from %v to %v without a socket", path[i-1].Value(), path[i].Value())
+		}
+	}
+}
+
+func TestRandomWalks(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+	gs.Bind(1, 2, 1)
+	gs.Bind(1, 3, 0)
+	gs.Bind(2, 1, 2)
+	gs.Bind(2, 4, 3)
+	gs.Bind(3, 1, 1)
+	gs.Bind(4, 2, 1)
+
+	a := RandomWalk(rand.New(rand.NewSource(7)), gs.Get(1), 50)
+	b := RandomWalk(rand.New(rand.NewSource(7)), gs.Get(1), 50)
+
+	if len(a) != 50 || fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Fatal("Expected walks of the same seed to match")
+	}
+
+	checkWalk(t, a)
+
+	if path := RandomWalk(rand.New(rand.NewSource(1)), gs.Get(5), 10); len(path) != 1 {
+		t.Fatalf("Expected a walk from a dead end to stop got %d nodes", len(path))
+	}
+
+	r := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 20; i++ {
+		path := WeightedRandomWalk(r, gs.Get(1), 30)
+		checkWalk(t, path)
+
+		for _, n := range path {
+			if n.Value() == 3 {
+				t.Fatal("Expected the zero weight socket to 3 never to be taken")
+			}
+		}
+	}
+
+	restart := RandomWalkWithRestart(rand.New(rand.NewSource(5)), gs.Get(4), 20, 1)
+
+	for _, n := range restart {
+		if n.Value() != 4 {
+			t.Fatal("Expected a certain restart to stay at the start node")
+		}
+	}
+
+	if path := RandomWalkWithRestart(rand.New(rand.NewSource(5)), gs.Get(5), 5, 0); len(path) != 5 {
+		t.Fatal("Expected dead ends to restart rather than stop")
+	}
+}
+
+func TestNode2VecWalk(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("a", "b", "c")
+	gs.Bind("a", "b", 1)
+	gs.Bind("b", "a", 1)
+	gs.Bind("b", "c", 1)
+	gs.Bind("c", "b", 1)
+
+	r := rand.New(rand.NewSource(11))
+
+	returns, outward := 0, 0
+
+	for i := 0; i < 200; i++ {
+		if path := Node2VecWalk(r, gs.Get("a"), 3, 0.001, 1); path[2].Value() == "a" {
+			returns++
+		}
+
+		if path := Node2VecWalk(r, gs.Get("a"), 3, 1, 0.001); path[2].Value() == "c" {
+			outward++
+		}
+	}
+
+	if returns < 190 || outward < 190 {
+		t.Fatalf("Expected p and q to bias the walk, got %d returns and %d outward", returns, outward)
+	}
+
+	for _, pq := range [][2]float64{{0, 1}, {1, 0}, {-1, 1}, {1, math.NaN()}} {
+		if path := Node2VecWalk(r, gs.Get("a"), 3, pq[0], pq[1]); path != nil {
+			t.Fatalf("Expected p %v and q %v to be refused got %d nodes", pq[0], pq[1], len(path))
+		}
+	}
+}
+
+func TestSampling(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5)
+	gs.Bind(1, 2, 1)
+	gs.Bind(1, 3, 0)
+	gs.Bind(2, 1, 2)
+	gs.Bind(2, 4, 3)
+	gs.Bind(3, 1, 1)
+	gs.Bind(4, 2, 1)
+	r := rand.New(rand.NewSource(9))
+
+	nodes := SampleNodes(r, gs, 3)
+	seen := VisitMaps()
+
+	for _, n := range nodes {
+		if seen[n] || n.Graph() != Graphs(gs) {
+			t.Fatal("Expected distinct nodes of the graph")
+		}
+		seen[n] = true
+	}
+
+	if len(nodes) != 3 || len(SampleNodes(r, gs, 10)) != 5 {
+		t.Fatal("Unexpected node sample sizes")
+	}
+
+	edges := SampleEdges(r, gs, 4)
+	unique := make(map[*Socket]bool)
+
+	for _, sc := range edges {
+		unique[sc] = true
+	}
+
+	if len(edges) != 4 || len(unique) != 4 || len(SampleEdges(r, gs, 100)) != 6 {
+		t.Fatal("Unexpected edge sample")
+	}
+
+	counts := make(map[interface{}]int)
+
+	for i := 0; i < 5000; i++ {
+		counts[SampleNodes(r, gs, 1)[0].Value()]++
+	}
+
+	for v, c := range counts {
+		if c < 850 || c > 1150 {
+			t.Fatalf("Expected a uniform sample, %v drawn %d times", v, c)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package ds
+
+//Schedule provides the critical path analysis of a weighted acyclic graph where each socket weight is the duration between its nodes
+type Schedule struct {
+	//Path is the chain of sockets making up the critical (longest) path
+	Path []*Socket
+	//Nodes are the nodes along the critical path in order
+	Nodes []Nodes
+	//Length is the total duration of the critical path
+	Length int
+	//Earliest holds the earliest start time of each node
+	Earliest map[Nodes]int
+	//Latest holds the latest start time of each node that does not delay the schedule
+	Latest map[Nodes]int
+	//Slack holds the amount of time each node can be delayed without delaying the schedule
+	Slack map[Nodes]int
+}
+
+//Critical returns true if the node has no slack i.e lies on a critical path
+func (s *Schedule) Critical(n Nodes) bool {
+	slack, ok := s.Slack[n]
+	return ok && slack == 0
+}
+
+//TopologicalSort returns the nodes of the graph in topological order or ErrCyclicGraph if the graph contains a cycle
+func TopologicalSort(g Graphs) ([]Nodes, error) {
+	nodes := g.nodeSet().AllNodes()
+	indegree := make(map[Nodes]int, len(nodes))
+
+	for _, n := range nodes {
+		indegree[n] = 0
+	}
+
+	for _, n := range nodes {
+		for _, sock := range OutSockets(n) {
+			if _, ok := indegree[sock.To]; ok {
+				indegree[sock.To]++
+			}
+		}
+	}
+
+	var queue []Nodes
+	for _, n := range nodes {
+		if indegree[n] == 0 {
+			queue = append(queue, n)
+		}
+	}
+
+	order := make([]Nodes, 0, len(nodes))
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		order = append(order, n)
+
+		for _, sock := range OutSockets(n) {
+			if _, ok := indegree[sock.To]; !ok {
+				continue
+			}
+
+			indegree[sock.To]--
+			if indegree[sock.To] == 0 {
+				queue = append(queue, sock.To)
+			}
+		}
+	}
+
+	if len(order) != len(nodes) {
+		return nil, ErrCyclicGraph
+	}
+
+	return order, nil
+}
+
+//CriticalPath returns the schedule of the graph using the weight of sockets as durations, returns ErrCyclicGraph if the graph is not acyclic
+func CriticalPath(g Graphs) (*Schedule, error) {
+	order, err := TopologicalSort(g)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(order) == 0 {
+		return nil, ErrEmpty
+	}
+
+	member := VisitMaps()
+	for _, n := range order {
+		member[n] = true
+	}
+
+	earliest := make(map[Nodes]int, len(order))
+	preds := make(map[Nodes]*Socket)
+
+	for _, n := range order {
+		for _, sock := range OutSockets(n) {
+			if !member[sock.To] {
+				continue
+			}
+
+			start := earliest[n] + sock.Weight
+
+			if _, ok := preds[sock.To]; !ok || start > earliest[sock.To] {
+				earliest[sock.To] = start
+				preds[sock.To] = sock
+			}
+		}
+	}
+
+	end := order[0]
+	for _, n := range order {
+		if earliest[n] > earliest[end] {
+			end = n
+		}
+	}
+
+	length := earliest[end]
+
+	latest := make(map[Nodes]int, len(order))
+	slack := make(map[Nodes]int, len(order))
+
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		latest[n] = length
+
+		for _, sock := range OutSockets(n) {
+			if !member[sock.To] {
+				continue
+			}
+
+			if start := latest[sock.To] - sock.Weight; start < latest[n] {
+				latest[n] = start
+			}
+		}
+
+		slack[n] = latest[n] - earliest[n]
+	}
+
+	var path []*Socket
+	nodes := []Nodes{end}
+
+	for cur := end; preds[cur] != nil; cur = preds[cur].From {
+		path = append([]*Socket{preds[cur]}, path...)
+		nodes = append([]Nodes{preds[cur].From}, nodes...)
+	}
+
+	return &Schedule{
+		Path:     path,
+		Nodes:    nodes,
+		Length:   length,
+		Earliest: earliest,
+		Latest:   latest,
+		Slack:    slack,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package ds
+
+import "testing"
+
+func TestCriticalPath(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("start", "build", "test", "docs", "ship")
+	gs.Bind("start", "build", 3)
+	gs.Bind("build", "test", 5)
+	gs.Bind("build", "docs", 1)
+	gs.Bind("test", "ship", 2)
+	gs.Bind("docs", "ship", 2)
+
+	sc, err := CriticalPath(gs)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sc.Length != 10 {
+		t.Fatalf("Expected critical path length of 10 got %d", sc.Length)
+	}
+
+	expected := []string{"start", "build", "test", "ship"}
+
+	if len(sc.Nodes) != len(expected) || len(sc.Path) != len(expected)-1 {
+		t.Fatalf("Expected critical path %v got %s", expected, sc.Nodes)
+	}
+
+	for i, v := range expected {
+		if sc.Nodes[i].Value() != v {
+			t.Fatalf("Expected %s at position %d of critical path got %s", v, i, sc.Nodes[i])
+		}
+	}
+
+	docs := gs.Get("docs")
+
+	if sc.Earliest[docs] != 4 || sc.Latest[docs] != 8 || sc.Slack[docs] != 4 {
+		t.Fatalf("Unexpected schedule for 'docs': %d %d %d", sc.Earliest[docs], sc.Latest[docs], sc.Slack[docs])
+	}
+
+	if !sc.Critical(gs.Get("test")) || sc.Critical(docs) {
+		t.Fatal("Incorrect critical nodes")
+	}
+}
+
+func TestCriticalPathCycle(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3)
+	gs.Bind(1, 2, 1)
+	gs.Bind(2, 3, 1)
+	gs.Bind(3, 2, 1)
+
+	if _, err := CriticalPath(gs); err != ErrCyclicGraph {
+		t.Fatalf("Expected ErrCyclicGraph got %v", err)
+	}
+}
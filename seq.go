@@ -0,0 +1,96 @@
+//go:build go1.23
+
+package ds
+
+import "iter"
+
+//All returns an iterator over the index and value of each element from root to tail
+func (d *DeferList) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		ind := 0
+		for cur := d.Root(); cur != nil; ind++ {
+			next := cur.Next()
+			if !yield(ind, cur.Value()) {
+				return
+			}
+			cur = next
+		}
+	}
+}
+
+//Backward returns an iterator over the index and value of each element from tail to root
+func (d *DeferList) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		ind := -1
+		for cur := d.Root(); cur != nil; cur = cur.Next() {
+			ind++
+		}
+
+		for cur := d.Tail(); cur != nil; ind-- {
+			prev := cur.Previous()
+			if !yield(ind, cur.Value()) {
+				return
+			}
+			cur = prev
+		}
+	}
+}
+
+//All returns an iterator over the index and node of each element of the set
+func (n *NodeSet) All() iter.Seq2[int, Nodes] {
+	return func(yield func(int, Nodes) bool) {
+		n.Each(func(nx Nodes, ind int, stop func()) {
+			if !yield(ind, nx) {
+				stop()
+			}
+		})
+	}
+}
+
+//Values returns an iterator over the strings of the set, unlike All it does not collect them into a slice
+func (n *StringSet) Values() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		n.Each(func(s string, _ int, stop func()) {
+			if !yield(s) {
+				stop()
+			}
+		})
+	}
+}
+
+//Edges returns an iterator over the nodes this node connects to along with their sockets
+func (n *Node) Edges() iter.Seq2[Nodes, *Socket] {
+	return func(yield func(Nodes, *Socket) bool) {
+		itr := n.Arcs()
+
+		for itr.Next() == nil {
+			sock, ok := itr.Value().(*Socket)
+			if !ok {
+				continue
+			}
+
+			if !yield(sock.To, sock) {
+				return
+			}
+		}
+	}
+}
+
+//Traverse returns an iterator over the nodes reached from the start node by the directive and the sockets they were reached by
+func Traverse(dir *TransversalDirective, start Nodes) iter.Seq2[Nodes, *Socket] {
+	return func(yield func(Nodes, *Socket) bool) {
+		trans, err := CreateGraphTransversor(dir)
+
+		if err != nil {
+			return
+		}
+
+		trans.Use(start)
+
+		for trans.Next() == nil {
+			if !yield(trans.Node(), trans.Key()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+//go:build go1.23
+
+package ds
+
+import "testing"
+
+func TestListSeq(t *testing.T) {
+	pack := List(1, 2, 3)
+
+	var forward []interface{}
+	for ind, v := range pack.All() {
+		if ind != len(forward) {
+			t.Fatalf("Expected index %d got %d", len(forward), ind)
+		}
+		forward = append(forward, v)
+	}
+
+	if len(forward) != 3 || forward[0] != 1 || forward[2] != 3 {
+		t.Fatalf("Unexpected forward values: %v", forward)
+	}
+
+	var backward []interface{}
+	for ind, v := range pack.Backward() {
+		if ind != 2-len(backward) {
+			t.Fatalf("Expected index %d got %d", 2-len(backward), ind)
+		}
+		backward = append(backward, v)
+		if len(backward) == 2 {
+			break
+		}
+	}
+
+	if len(backward) != 2 || backward[0] != 3 || backward[1] != 2 {
+		t.Fatalf("Unexpected backward values: %v", backward)
+	}
+}
+
+func TestSetSeq(t *testing.T) {
+	vs := NewStringSet()
+	vs.Add("alex")
+	vs.Add("john")
+
+	var names []string
+	for name := range vs.Values() {
+		names = append(names, name)
+		break
+	}
+
+	if len(names) != 1 || names[0] != "alex" {
+		t.Fatalf("Expected to stop after 'alex' got %v", names)
+	}
+
+	gs := NewGraph()
+	gs.Add(1, 2, 3)
+
+	count := 0
+	for _, n := range gs.nodeSet().All() {
+		if n == nil {
+			t.Fatal("Received a nil node")
+		}
+		count++
+	}
+
+	if count != 3 {
+		t.Fatalf("Expected 3 nodes got %d", count)
+	}
+}
+
+func TestGraphSeq(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 3, 4, 7)
+	gs.Bind(1, 3, 0)
+	gs.Bind(1, 7, 0)
+	gs.Bind(3, 4, 0)
+
+	root := gs.Get(1).(*Node)
+
+	var edges []interface{}
+	for to, soc := range root.Edges() {
+		if soc.From != root {
+			t.Fatal("Expected socket to leave the root node")
+		}
+		edges = append(edges, to.Value())
+	}
+
+	if len(edges) != 2 || edges[0] != 3 || edges[1] != 7 {
+		t.Fatalf("Unexpected edges: %v", edges)
+	}
+
+	var path []interface{}
+	for n, soc := range Traverse(DFPreOrderDirective(nil, nil), root) {
+		if len(path) == 0 && soc != nil {
+			t.Fatal("Expected no socket for the start node")
+		}
+		path = append(path, n.Value())
+		if n.Value() == 4 {
+			break
+		}
+	}
+
+	if len(path) != 3 || path[2] != 4 {
+		t.Fatalf("Unexpected traversal: %v", path)
+	}
+
+	for range Traverse(&TransversalDirective{Order: "sideways"}, root) {
+		t.Fatal("Expected an unknown order to yield nothing")
+	}
+}
@@ -0,0 +1,164 @@
+package ds
+
+import (
+	"math"
+	"sort"
+)
+
+//Similarity defines a function scoring how alike two nodes are
+type Similarity func(a, b Nodes) float64
+
+//ScoredNode pairs a node with its similarity score
+type ScoredNode struct {
+	Node  Nodes
+	Score float64
+}
+
+//SimilarityIndex provides similarity and link prediction scores between nodes of a graph based on their socket neighbourhoods, sockets are treated as undirected
+type SimilarityIndex struct {
+	nodes []Nodes
+	adj   map[Nodes]NodeMaps
+}
+
+//NewSimilarityIndex returns a SimilarityIndex for the current state of the graph
+func NewSimilarityIndex(g Graphs) *SimilarityIndex {
+	nodes, adj := neighbourhoods(g)
+	return &SimilarityIndex{
+		nodes: nodes,
+		adj:   adj,
+	}
+}
+
+//similarityIndexOf returns the index for the graph of the node
+func similarityIndexOf(n Nodes) *SimilarityIndex {
+	if n.Graph() == nil {
+		return &SimilarityIndex{adj: make(map[Nodes]NodeMaps)}
+	}
+	return NewSimilarityIndex(n.Graph())
+}
+
+//Neighbours returns the neighbour set of the node
+func (s *SimilarityIndex) Neighbours(n Nodes) NodeMaps {
+	return s.adj[n]
+}
+
+//common returns the neighbours shared by both nodes
+func (s *SimilarityIndex) common(a, b Nodes) []Nodes {
+	var shared []Nodes
+
+	na, nb := s.adj[a], s.adj[b]
+
+	if len(nb) < len(na) {
+		na, nb = nb, na
+	}
+
+	for n := range na {
+		if nb[n] {
+			shared = append(shared, n)
+		}
+	}
+
+	return shared
+}
+
+//CommonNeighbours returns the number of neighbours shared by both nodes
+func (s *SimilarityIndex) CommonNeighbours(a, b Nodes) float64 {
+	return float64(len(s.common(a, b)))
+}
+
+//Jaccard returns the ratio of shared neighbours to the union of both neighbourhoods
+func (s *SimilarityIndex) Jaccard(a, b Nodes) float64 {
+	shared := len(s.common(a, b))
+	union := len(s.adj[a]) + len(s.adj[b]) - shared
+
+	if union == 0 {
+		return 0
+	}
+
+	return float64(shared) / float64(union)
+}
+
+//Cosine returns the Salton cosine similarity of both neighbourhoods
+func (s *SimilarityIndex) Cosine(a, b Nodes) float64 {
+	degrees := len(s.adj[a]) * len(s.adj[b])
+
+	if degrees == 0 {
+		return 0
+	}
+
+	return float64(len(s.common(a, b))) / math.Sqrt(float64(degrees))
+}
+
+//AdamicAdar returns the sum of the inverse log degree of the shared neighbours
+func (s *SimilarityIndex) AdamicAdar(a, b Nodes) float64 {
+	var score float64
+
+	for _, n := range s.common(a, b) {
+		if deg := len(s.adj[n]); deg > 1 {
+			score += 1 / math.Log(float64(deg))
+		}
+	}
+
+	return score
+}
+
+//PreferentialAttachment returns the product of the degrees of both nodes
+func (s *SimilarityIndex) PreferentialAttachment(a, b Nodes) float64 {
+	return float64(len(s.adj[a]) * len(s.adj[b]))
+}
+
+//TopK returns up to k nodes most similar to n according to the metric, leaving out nodes n is connected to
+func (s *SimilarityIndex) TopK(n Nodes, k int, metric Similarity) []ScoredNode {
+	var scored []ScoredNode
+
+	for _, c := range s.nodes {
+		if c == n || s.adj[n][c] {
+			continue
+		}
+
+		if score := metric(n, c); score > 0 {
+			scored = append(scored, ScoredNode{Node: c, Score: score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	if k > 0 && len(scored) > k {
+		scored = scored[:k]
+	}
+
+	return scored
+}
+
+//CommonNeighbours returns the number of neighbours shared by both nodes within the graph of a
+func CommonNeighbours(a, b Nodes) float64 {
+	return similarityIndexOf(a).CommonNeighbours(a, b)
+}
+
+//Jaccard returns the jaccard similarity of both nodes within the graph of a
+func Jaccard(a, b Nodes) float64 {
+	return similarityIndexOf(a).Jaccard(a, b)
+}
+
+//Cosine returns the cosine similarity of both nodes within the graph of a
+func Cosine(a, b Nodes) float64 {
+	return similarityIndexOf(a).Cosine(a, b)
+}
+
+//AdamicAdar returns the Adamic-Adar score of both nodes within the graph of a
+func AdamicAdar(a, b Nodes) float64 {
+	return similarityIndexOf(a).AdamicAdar(a, b)
+}
+
+//PreferentialAttachment returns the preferential-attachment score of both nodes within the graph of a
+func PreferentialAttachment(a, b Nodes) float64 {
+	return similarityIndexOf(a).PreferentialAttachment(a, b)
+}
+
+//TopKSimilar returns up to k nodes of the graph of n which n is not yet connected to, ranked by jaccard similarity
+func TopKSimilar(n Nodes, k int) []ScoredNode {
+	idx := similarityIndexOf(n)
+	return idx.TopK(n, k, idx.Jaccard)
+}
@@ -0,0 +1,94 @@
+package ds
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimilarityScores(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("ann", "bob", "cat", "dan", "eve")
+	gs.Bind("ann", "cat", 0)
+	gs.Bind("ann", "dan", 0)
+	gs.Bind("bob", "cat", 0)
+	gs.Bind("dan", "bob", 0)
+	gs.Bind("eve", "cat", 0)
+	ann, bob, eve := gs.Get("ann"), gs.Get("bob"), gs.Get("eve")
+
+	if c := CommonNeighbours(ann, bob); c != 2 {
+		t.Fatalf("Expected 2 common neighbours got %f", c)
+	}
+
+	if j := Jaccard(ann, bob); j != 1 {
+		t.Fatalf("Expected jaccard of 1 got %f", j)
+	}
+
+	if j := Jaccard(ann, eve); j != 0.5 {
+		t.Fatalf("Expected jaccard of 0.5 got %f", j)
+	}
+
+	if c := Cosine(ann, eve); math.Abs(c-1/math.Sqrt(2)) > 1e-9 {
+		t.Fatalf("Expected cosine of 1/sqrt(2) got %f", c)
+	}
+
+	expected := 1/math.Log(3) + 1/math.Log(2)
+	if a := AdamicAdar(ann, bob); math.Abs(a-expected) > 1e-9 {
+		t.Fatalf("Expected adamic-adar of %f got %f", expected, a)
+	}
+
+	if p := PreferentialAttachment(ann, eve); p != 2 {
+		t.Fatalf("Expected preferential-attachment of 2 got %f", p)
+	}
+}
+
+func TestTopKSimilar(t *testing.T) {
+	gs := NewGraph()
+	gs.Add("ann", "bob", "cat", "dan", "eve")
+	gs.Bind("ann", "cat", 0)
+	gs.Bind("ann", "dan", 0)
+	gs.Bind("bob", "cat", 0)
+	gs.Bind("dan", "bob", 0)
+	gs.Bind("eve", "cat", 0)
+
+	top := TopKSimilar(gs.Get("ann"), 2)
+
+	if len(top) != 2 {
+		t.Fatalf("Expected 2 similar nodes got %d", len(top))
+	}
+
+	if top[0].Node.Value() != "bob" || top[1].Node.Value() != "eve" {
+		t.Fatalf("Unexpected ranking: %s, %s", top[0].Node, top[1].Node)
+	}
+
+	idx := NewSimilarityIndex(gs)
+
+	all := idx.TopK(gs.Get("ann"), 0, idx.AdamicAdar)
+
+	if len(all) != 2 {
+		t.Fatalf("Expected a k of zero to return both candidates got %d", len(all))
+	}
+
+	for _, sc := range all {
+		if sc.Node.Value() == "cat" || sc.Node.Value() == "dan" {
+			t.Fatalf("Expected connected node %s to be left out", sc.Node)
+		}
+	}
+
+	tail := NewGraph()
+	tail.Add("p", "q", "r")
+	tail.Bind("p", "q", 0)
+
+	if top := TopKSimilar(tail.Get("p"), 0); len(top) != 0 {
+		t.Fatalf("Expected nodes without a positive score to be left out got %s", top[0].Node)
+	}
+
+	tri := NewGraph()
+	tri.Add("x", "y", "z")
+	tri.Bind("x", "y", 0)
+	tri.Bind("z", "x", 0)
+	tri.Bind("z", "y", 0)
+
+	if top := TopKSimilar(tri.Get("y"), 0); len(top) != 0 {
+		t.Fatalf("Expected in-neighbours of y to be left out got %s", top[0].Node)
+	}
+}
@@ -0,0 +1,79 @@
+package ds
+
+import (
+	"context"
+
+	"github.com/influx6/sequence"
+)
+
+//DefaultStreamBuffer is the number of steps Stream lets its producer run ahead of the consumer
+const DefaultStreamBuffer = 64
+
+//Step is a node produced by a streamed transversal, a Step with a non-nil Err is the last one sent
+type Step struct {
+	Node   Nodes
+	Socket *Socket
+	Depth  int
+	Err    error
+}
+
+//Stream runs the transversal of the directive from the start node in a goroutine and sends each node it visits on the returned channel, see StreamBuffered
+func Stream(ctx context.Context, dir *TransversalDirective, start Nodes) <-chan Step {
+	return StreamBuffered(ctx, dir, start, DefaultStreamBuffer)
+}
+
+//StreamBuffered runs the transversal in a goroutine, sending each node on a channel that holds up to size steps
+func StreamBuffered(ctx context.Context, dir *TransversalDirective, start Nodes, size int) <-chan Step {
+	if size < 0 {
+		size = 0
+	}
+
+	out := make(chan Step, size)
+
+	go func() {
+		defer close(out)
+
+		send := func(step Step) bool {
+			select {
+			case out <- step:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		trans, err := CreateGraphTransversor(dir)
+
+		if err != nil {
+			send(Step{Err: err})
+			return
+		}
+
+		trans.Use(start)
+
+		for {
+			err := trans.NextContext(ctx)
+
+			if err == ErrBadIndex || err == sequence.ErrBADINDEX || err == sequence.ErrENDINDEX {
+				return
+			}
+
+			if err != nil {
+				if ctx.Err() == nil {
+					send(Step{Err: err})
+				}
+				return
+			}
+
+			if !send(Step{
+				Node:   trans.Node(),
+				Socket: trans.Key(),
+				Depth:  trans.WalkDepth(),
+			}) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
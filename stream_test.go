@@ -0,0 +1,163 @@
+package ds
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	_, root := chainGraph(500)
+
+	count := 0
+
+	for step := range Stream(context.Background(), DFPreOrderDirective(nil, nil), root) {
+		if step.Err != nil {
+			t.Fatal(step.Err)
+		}
+
+		if step.Depth != count {
+			t.Fatalf("Expected depth %d got %d", count, step.Depth)
+		}
+
+		if count > 0 && step.Socket.To != step.Node {
+			t.Fatal("Expected the step socket to lead to its node")
+		}
+
+		count++
+	}
+
+	if count != 500 {
+		t.Fatalf("Expected 500 steps got %d", count)
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	_, root := chainGraph(10)
+
+	var steps []Step
+	for step := range Stream(context.Background(), &TransversalDirective{Order: "sideways"}, root) {
+		steps = append(steps, step)
+	}
+
+	if len(steps) != 1 || steps[0].Err == nil {
+		t.Fatal("Expected a single error step for an unknown order")
+	}
+
+	dir := BFPreOrderDirective(nil, nil)
+	dir.MaxVisits = 3
+
+	steps = nil
+	for step := range Stream(context.Background(), dir, root) {
+		steps = append(steps, step)
+	}
+
+	if len(steps) != 4 || steps[3].Err != ErrVisitLimit {
+		t.Fatalf("Expected 3 nodes and the visit limit got %d steps", len(steps))
+	}
+}
+
+func TestStreamBackpressure(t *testing.T) {
+	_, root := wideGraph(1000)
+
+	const size = 2
+
+	var produced int64
+	reached := make(chan int64, 1000)
+
+	dir := BFPreOrderDirective(nil, func(Nodes, *Socket) error {
+		reached <- atomic.AddInt64(&produced, 1)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	steps := StreamBuffered(ctx, dir, root, size)
+
+	wait := func(n int64) {
+		for got := range reached {
+			if got == n {
+				return
+			}
+		}
+	}
+
+	wait(size)
+
+	if n := atomic.LoadInt64(&produced); n != size {
+		t.Fatalf("Expected the producer to wait on a full buffer after %d children, it ran to %d", size, n)
+	}
+
+	<-steps
+	wait(size + 1)
+
+	if n := atomic.LoadInt64(&produced); n != size+1 {
+		t.Fatalf("Expected one consumed step to release one more child, the producer ran to %d", n)
+	}
+
+	cancel()
+
+	for range steps {
+	}
+}
+
+func TestStreamShared(t *testing.T) {
+	_, root := chainGraph(200)
+
+	dir := DFPreOrderDirective(nil, nil)
+
+	one := Stream(context.Background(), dir, root)
+	two := Stream(context.Background(), dir, root)
+
+	var a, b int
+
+	for one != nil || two != nil {
+		select {
+		case step, ok := <-one:
+			if !ok {
+				one = nil
+				continue
+			}
+			if step.Err != nil {
+				t.Fatal(step.Err)
+			}
+			a++
+		case step, ok := <-two:
+			if !ok {
+				two = nil
+				continue
+			}
+			if step.Err != nil {
+				t.Fatal(step.Err)
+			}
+			b++
+		}
+	}
+
+	if a != 200 || b != 200 {
+		t.Fatalf("Expected both streams sharing a directive to walk 200 nodes got %d and %d", a, b)
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	_, root := chainGraph(1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	steps := StreamBuffered(ctx, DFPreOrderDirective(nil, nil), root, 0)
+
+	<-steps
+	cancel()
+
+	count := 0
+	for step := range steps {
+		if step.Err != nil {
+			t.Fatal("Expected no error step once the context is done")
+		}
+		count++
+	}
+
+	if count > 1 {
+		t.Fatalf("Expected the stream to close once the context is done, it sent %d more steps", count)
+	}
+}
@@ -1,6 +1,12 @@
 package ds
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/influx6/sequence"
+)
 
 func TestDFSPreHeuristic(t *testing.T) {
 	var gs = NewGraph()
@@ -322,3 +328,557 @@ func TestFilter(t *testing.T) {
 
 	t.Logf("Path: %+s", filter.Nodes())
 }
+
+func orderGraphs() []*Graph {
+	dag := NewGraph()
+	dag.Add(1, 3, 4, 5, 6, 7, 8)
+	dag.Bind(1, 3, 0)
+	dag.Bind(3, 4, 0)
+	dag.Bind(8, 3, 0)
+	dag.Bind(4, 8, 0)
+	dag.Bind(1, 7, 0)
+	dag.Bind(7, 6, 0)
+
+	cyclic := NewGraph()
+	cyclic.Add(1, 3, 4, 5, 6, 7)
+	cyclic.Bind(1, 3, 0)
+	cyclic.Bind(3, 4, 0)
+	cyclic.Bind(4, 6, 0)
+	cyclic.Bind(4, 5, 0)
+	cyclic.Bind(5, 4, 0)
+	cyclic.Bind(1, 7, 0)
+	cyclic.Bind(1, 6, 0)
+	cyclic.Bind(6, 3, 0)
+
+	return []*Graph{dag, cyclic}
+}
+
+func TestTransversalOrders(t *testing.T) {
+	graphs := orderGraphs()
+
+	heuristics := []NodeOp{nil, func(n Nodes, _ *Socket) error {
+		if n.Value() == 4 || n.Value() == 6 {
+			return ErrBadNode
+		}
+		return nil
+	}}
+
+	cases := []struct {
+		graph     int
+		order     TransversalOrder
+		heuristic int
+		depth     int
+		path      string
+		end       error
+	}{
+		{0, DFPreOrder, 0, -1, "1:nil:0 3:1:1 4:3:2 8:4:3 7:1:1 6:7:2 ", sequence.ErrBADINDEX},
+		{0, DFPreOrder, 0, 2, "1:nil:0 3:1:1 ", ErrBadIndex},
+		{0, DFPreOrder, 1, -1, "1:nil:0 3:1:1 7:1:2 ", sequence.ErrBADINDEX},
+		{0, DFPreOrder, 1, 2, "1:nil:0 3:1:1 ", ErrBadIndex},
+		{0, DFPostOrder, 0, -1, "8:4:3 4:3:2 3:1:1 6:7:2 7:1:1 1:nil:0 ", ErrBadIndex},
+		{0, DFPostOrder, 0, 2, "", ErrBadIndex},
+		{0, DFPostOrder, 1, -1, "3:1:1 7:1:2 1:nil:1 ", ErrBadIndex},
+		{0, DFPostOrder, 1, 2, "", ErrBadIndex},
+		{0, BFPreOrder, 0, -1, "1:nil:0 3:1:1 7:1:1 4:3:2 6:7:3 8:4:4 ", sequence.ErrBADINDEX},
+		{0, BFPreOrder, 0, 2, "1:nil:0 3:1:1 7:1:1 4:3:2 ", ErrBadIndex},
+		{0, BFPreOrder, 1, -1, "1:nil:0 3:1:1 7:1:1 ", sequence.ErrBADINDEX},
+		{0, BFPreOrder, 1, 2, "1:nil:0 3:1:1 7:1:1 ", ErrBadIndex},
+		{0, BFPostOrder, 0, -1, "3:1:1 7:1:1 1:nil:0 4:3:2 6:7:2 8:4:3 ", ErrBadIndex},
+		{0, BFPostOrder, 0, 2, "3:1:1 7:1:1 1:nil:0 4:3:2 ", ErrBadIndex},
+		{0, BFPostOrder, 1, -1, "3:1:1 7:1:1 1:nil:0 ", ErrBadIndex},
+		{0, BFPostOrder, 1, 2, "3:1:1 7:1:1 1:nil:0 ", ErrBadIndex},
+		{1, DFPreOrder, 0, -1, "1:nil:0 3:1:1 4:3:2 6:4:3 5:4:3 7:1:1 ", sequence.ErrBADINDEX},
+		{1, DFPreOrder, 0, 2, "1:nil:0 3:1:1 ", ErrBadIndex},
+		{1, DFPreOrder, 1, -1, "1:nil:0 3:1:1 7:1:2 ", sequence.ErrBADINDEX},
+		{1, DFPreOrder, 1, 2, "1:nil:0 3:1:1 ", ErrBadIndex},
+		{1, DFPostOrder, 0, -1, "6:4:3 5:4:4 4:3:3 3:1:2 7:1:2 1:nil:2 ", ErrBadIndex},
+		{1, DFPostOrder, 0, 2, "", ErrBadIndex},
+		{1, DFPostOrder, 1, -1, "3:1:1 7:1:1 1:nil:1 ", ErrBadIndex},
+		{1, DFPostOrder, 1, 2, "", ErrBadIndex},
+		{1, BFPreOrder, 0, -1, "1:nil:0 3:1:1 7:1:1 6:1:1 4:3:2 5:4:4 ", sequence.ErrBADINDEX},
+		{1, BFPreOrder, 0, 2, "1:nil:0 3:1:1 7:1:1 6:1:1 4:3:2 ", ErrBadIndex},
+		{1, BFPreOrder, 1, -1, "1:nil:0 3:1:1 7:1:1 ", sequence.ErrBADINDEX},
+		{1, BFPreOrder, 1, 2, "1:nil:0 3:1:1 7:1:1 ", ErrBadIndex},
+		{1, BFPostOrder, 0, -1, "3:1:1 7:1:1 6:1:1 1:nil:0 4:3:2 5:4:3 ", ErrBadIndex},
+		{1, BFPostOrder, 0, 2, "3:1:1 7:1:1 6:1:1 1:nil:0 4:3:2 ", ErrBadIndex},
+		{1, BFPostOrder, 1, -1, "3:1:1 7:1:1 1:nil:0 ", ErrBadIndex},
+		{1, BFPostOrder, 1, 2, "3:1:1 7:1:1 1:nil:0 ", ErrBadIndex},
+	}
+
+	for _, c := range cases {
+		var path string
+
+		proc, err := Search(func(n Nodes, soc *Socket, depth int) error {
+			from := "nil"
+			if soc != nil {
+				from = fmt.Sprint(soc.From.Value())
+			}
+			path += fmt.Sprintf("%v:%s:%d ", n.Value(), from, depth)
+			return nil
+		}, MakeTransversalDirective(c.depth, c.order, nil, heuristics[c.heuristic]))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proc.Use(graphs[c.graph].Get(1))
+
+		for err = proc.Next(); err == nil; err = proc.Next() {
+		}
+
+		if path != c.path || err != c.end {
+			t.Fatalf("Unexpected %s path with heuristic %d and depth %d on graph %d: %q (%v) expected %q (%v)", c.order, c.heuristic, c.depth, c.graph, path, err, c.path, c.end)
+		}
+	}
+}
+
+func TestTransversalContext(t *testing.T) {
+	_, root := chainGraph(100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	proc, err := Search(func(n Nodes, _ *Socket, _ int) error {
+		if n.Value() == 10 {
+			cancel()
+		}
+		return nil
+	}, DFPreOrderDirective(nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proc.Use(root)
+
+	count := 0
+	for err = proc.NextContext(ctx); err == nil; err = proc.NextContext(ctx) {
+		count++
+	}
+
+	if err != context.Canceled {
+		t.Fatalf("Expected context.Canceled got %v", err)
+	}
+
+	if count != 11 {
+		t.Fatalf("Expected 11 visited nodes before cancellation got %d", count)
+	}
+}
+
+func TestTransversalVisitLimit(t *testing.T) {
+	_, root := chainGraph(100)
+
+	dir := BFPreOrderDirective(nil, nil)
+	dir.MaxVisits = 5
+
+	builder, err := Filter(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := builder.Transverse(root)
+
+	for err = filter.Next(); err == nil; err = filter.Next() {
+	}
+
+	if err != ErrVisitLimit {
+		t.Fatalf("Expected ErrVisitLimit got %v", err)
+	}
+
+	if len(filter.Nodes()) != 5 {
+		t.Fatalf("Expected 5 visited nodes got %d", len(filter.Nodes()))
+	}
+}
+
+func TestLinearSearchContext(t *testing.T) {
+	gs, _ := chainGraph(10)
+	search := NewLinearGraphSearch(gs)
+
+	found, err := search.FindAll(func(n Nodes) bool {
+		return n.Value().(int)%2 == 0
+	})
+
+	if err != nil || len(found) != 5 {
+		t.Fatalf("Expected 5 even nodes got %d (%v)", len(found), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := search.FindAllContext(ctx, func(Nodes) bool { return true }); err != context.Canceled {
+		t.Fatalf("Expected context.Canceled got %v", err)
+	}
+}
+
+func TestTransversalPaths(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5, 6)
+	gs.Bind(1, 2, 1)
+	gs.Bind(1, 3, 1)
+	gs.Bind(2, 4, 1)
+	gs.Bind(3, 4, 1)
+	gs.Bind(4, 5, 1)
+
+	proc, err := Search(func(Nodes, *Socket, int) error {
+		return nil
+	}, BFPreOrderDirective(nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proc.Use(gs.Get(1))
+
+	for proc.Next() == nil {
+	}
+
+	path := proc.PathTo(gs.Get(5))
+
+	if len(path) != 3 {
+		t.Fatalf("Expected a path of 3 sockets got %d", len(path))
+	}
+
+	if path[0].From.Value() != 1 || path[1].From.Value() != 2 || path[2].To.Value() != 5 {
+		t.Fatal("Unexpected shortest path to 5")
+	}
+
+	if proc.Parent(gs.Get(4)).Value() != 2 {
+		t.Fatalf("Expected parent of 4 to be 2 got %s", proc.Parent(gs.Get(4)))
+	}
+
+	if proc.Parent(gs.Get(1)) != nil || len(proc.PathTo(gs.Get(1))) != 0 {
+		t.Fatal("Expected start node to have no parent and an empty path")
+	}
+
+	if proc.PathTo(gs.Get(6)) != nil {
+		t.Fatal("Expected no path to an unreached node")
+	}
+
+	tree := proc.BFSTree()
+
+	if tree.Length() != 5 {
+		t.Fatalf("Expected tree of 5 nodes got %d", tree.Length())
+	}
+
+	if !tree.IsBound(2, 4) || tree.IsBound(3, 4) {
+		t.Fatal("Expected tree to only keep the discovering socket of 4")
+	}
+}
+
+func TestTransversalDirections(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+	gs.Bind(1, 2, 1)
+	gs.Bind(2, 3, 1)
+	gs.Bind(4, 2, 1)
+
+	cases := []struct {
+		direction Direction
+		start     int
+		reached   int
+	}{
+		{"", 3, 1},
+		{Out, 1, 3},
+		{In, 3, 4},
+		{In, 1, 1},
+		{Both, 1, 4},
+	}
+
+	for _, order := range []TransversalOrder{DFPreOrder, DFPostOrder, BFPreOrder, BFPostOrder} {
+		for _, c := range cases {
+			dir := MakeTransversalDirective(-1, order, nil, nil)
+			dir.Direction = c.direction
+
+			proc, err := Search(func(Nodes, *Socket, int) error {
+				return nil
+			}, dir)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			proc.Use(gs.Get(c.start))
+
+			count := 0
+			for proc.Next() == nil {
+				count++
+			}
+
+			if count != c.reached {
+				t.Fatalf("%s %q from %d: expected %d nodes got %d", order, c.direction, c.start, c.reached, count)
+			}
+		}
+	}
+
+	dir := BFPreOrderDirective(nil, nil)
+	dir.Direction = In
+
+	proc, _ := Search(func(Nodes, *Socket, int) error {
+		return nil
+	}, dir)
+
+	proc.Use(gs.Get(3))
+
+	for proc.Next() == nil {
+	}
+
+	if proc.Parent(gs.Get(1)).Value() != 2 || proc.Parent(gs.Get(4)).Value() != 2 {
+		t.Fatal("Expected 1 and 4 to be reached from 2 along incoming sockets")
+	}
+
+	if path := proc.PathTo(gs.Get(1)); len(path) != 2 || path[0].To.Value() != 3 || path[1].From.Value() != 1 {
+		t.Fatal("Unexpected incoming path to 1")
+	}
+
+	if tree := proc.BFSTree(); !tree.IsBound(3, 2) || !tree.IsBound(2, 4) {
+		t.Fatal("Expected tree sockets to lead from parent to child")
+	}
+}
+
+func TestTransversalRejectedDiscovery(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 4, 9)
+	gs.Bind(1, 4, 0)
+	gs.Bind(2, 9, 0)
+
+	blocked, _ := gs.Bind(4, 9, 0)
+	blocked.Attrs.Add("blocked")
+
+	reject := func(_ Nodes, soc *Socket) error {
+		if soc.Attrs.Has("blocked") {
+			return ErrBadNode
+		}
+		return nil
+	}
+
+	for _, order := range []TransversalOrder{DFPreOrder, DFPostOrder, BFPreOrder, BFPostOrder} {
+		trans, err := CreateGraphTransversor(MakeTransversalDirective(-1, order, nil, reject))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trans.UseMany(gs.Get(1), gs.Get(2))
+
+		for trans.Next() == nil {
+		}
+
+		nine := gs.Get(9)
+
+		if root := trans.Root(nine); root == nil || root.Value() != 2 {
+			t.Fatalf("%s: expected 9 to be rooted at 2 got %v", order, root)
+		}
+
+		if parent := trans.Parent(nine); parent == nil || parent.Value() != 2 {
+			t.Fatalf("%s: expected 9 to have parent 2 got %v", order, parent)
+		}
+
+		if path := trans.PathTo(nine); len(path) != 1 || path[0].From.Value() != 2 {
+			t.Fatalf("%s: expected the path to 9 to come from 2 got %v", order, path)
+		}
+	}
+}
+
+func TestTransversalMultiSource(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5, 6)
+	gs.Bind(1, 2, 1)
+	gs.Bind(2, 3, 1)
+	gs.Bind(4, 5, 1)
+	gs.Bind(5, 3, 1)
+
+	var order []interface{}
+
+	proc, err := Search(func(n Nodes, _ *Socket, _ int) error {
+		order = append(order, n.Value())
+		return nil
+	}, BFPreOrderDirective(nil, nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proc.UseMany(gs.Get(1), gs.Get(4))
+
+	for proc.Next() == nil {
+	}
+
+	if fmt.Sprint(order) != "[1 4 2 5 3]" {
+		t.Fatalf("Unexpected multi-source order %v", order)
+	}
+
+	if proc.Root(gs.Get(5)).Value() != 4 || proc.Root(gs.Get(3)).Value() != 1 {
+		t.Fatal("Expected nodes to be attributed to their nearest source")
+	}
+
+	if proc.Parent(gs.Get(4)) != nil || proc.Root(gs.Get(6)) != nil {
+		t.Fatal("Expected sources to have no parent and unreached nodes no root")
+	}
+
+	if path := proc.PathTo(gs.Get(5)); len(path) != 1 || path[0].From.Value() != 4 {
+		t.Fatal("Expected path to 5 to start from its own source")
+	}
+
+	order = nil
+	proc, _ = Search(func(n Nodes, _ *Socket, _ int) error {
+		order = append(order, n.Value())
+		return nil
+	}, DFPreOrderDirective(nil, nil))
+
+	proc.UseMany(gs.Get(1), gs.Get(4))
+
+	for proc.Next() == nil {
+	}
+
+	if fmt.Sprint(order) != "[1 2 3 4 5]" {
+		t.Fatalf("Unexpected sequential sources order %v", order)
+	}
+}
+
+func TestTransversalAllNodes(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4, 5, 6)
+	gs.Bind(1, 2, 1)
+	gs.Bind(2, 3, 1)
+	gs.Bind(4, 5, 1)
+	gs.Bind(5, 3, 1)
+
+	for _, order := range []TransversalOrder{DFPreOrder, DFPostOrder, BFPreOrder, BFPostOrder} {
+		dir := MakeTransversalDirective(-1, order, nil, nil)
+		dir.AllNodes = true
+
+		trans, err := CreateGraphTransversor(dir)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trans.Use(gs.Get(2))
+
+		count := 0
+		for trans.Next() == nil {
+			count++
+		}
+
+		if count != 6 {
+			t.Fatalf("%s: expected all 6 nodes got %d", order, count)
+		}
+
+		if len(trans.Unvisited(gs)) != 0 {
+			t.Fatalf("%s: expected no unvisited nodes", order)
+		}
+
+		roots := map[interface{}]interface{}{2: 2, 3: 2, 1: 1, 4: 4, 5: 4, 6: 6}
+
+		for v, r := range roots {
+			if root := trans.Root(gs.Get(v)); root == nil || root.Value() != r {
+				t.Fatalf("%s: expected %v to belong to the tree of %v got %v", order, v, r, root)
+			}
+		}
+	}
+}
+
+func chainGraph(size int) (*Graph, Nodes) {
+	gs := NewGraph()
+	root := NewGraphNode(0, gs)
+	gs.AddNode(root)
+
+	prev := Nodes(root)
+	for i := 1; i < size; i++ {
+		nx := NewGraphNode(i, gs)
+		gs.AddNode(nx)
+		prev.Connect(nx, 0)
+		prev = nx
+	}
+
+	return gs, root
+}
+
+func wideGraph(size int) (*Graph, Nodes) {
+	gs := NewGraph()
+	root := NewGraphNode(0, gs)
+	gs.AddNode(root)
+
+	for i := 1; i < size; i++ {
+		nx := NewGraphNode(i, gs)
+		gs.AddNode(nx)
+		root.Sockets().AppendElement(NewSocket(root, nx, 0))
+		nx.Connect(root, 0)
+	}
+
+	return gs, root
+}
+
+func TestDeepChainTransversal(t *testing.T) {
+	_, root := chainGraph(100000)
+
+	for _, order := range []TransversalOrder{DFPreOrder, DFPostOrder, BFPreOrder, BFPostOrder} {
+		trans, err := CreateGraphTransversor(MakeTransversalDirective(-1, order, nil, nil))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trans.Use(root)
+
+		count := 0
+		for trans.Next() == nil {
+			count++
+		}
+
+		if count != 100000 {
+			t.Fatalf("Expected %s to visit 100000 nodes got %d", order, count)
+		}
+	}
+}
+
+func benchmarkTransversal(b *testing.B, order TransversalOrder, root Nodes) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		trans, _ := CreateGraphTransversor(MakeTransversalDirective(-1, order, nil, nil))
+		trans.Use(root)
+		for trans.Next() == nil {
+		}
+	}
+}
+
+func BenchmarkDFSPreDeep(b *testing.B) {
+	_, root := chainGraph(1000000)
+	benchmarkTransversal(b, DFPreOrder, root)
+}
+
+func BenchmarkDFSPostDeep(b *testing.B) {
+	_, root := chainGraph(1000000)
+	benchmarkTransversal(b, DFPostOrder, root)
+}
+
+func BenchmarkBFSPreDeep(b *testing.B) {
+	_, root := chainGraph(1000000)
+	benchmarkTransversal(b, BFPreOrder, root)
+}
+
+func BenchmarkBFSPostDeep(b *testing.B) {
+	_, root := chainGraph(1000000)
+	benchmarkTransversal(b, BFPostOrder, root)
+}
+
+func BenchmarkDFSPreWide(b *testing.B) {
+	_, root := wideGraph(100000)
+	benchmarkTransversal(b, DFPreOrder, root)
+}
+
+func BenchmarkDFSPostWide(b *testing.B) {
+	_, root := wideGraph(100000)
+	benchmarkTransversal(b, DFPostOrder, root)
+}
+
+func BenchmarkBFSPreWide(b *testing.B) {
+	_, root := wideGraph(100000)
+	benchmarkTransversal(b, BFPreOrder, root)
+}
+
+func BenchmarkBFSPostWide(b *testing.B) {
+	_, root := wideGraph(100000)
+	benchmarkTransversal(b, BFPostOrder, root)
+}
@@ -0,0 +1,225 @@
+package ds
+
+//Visitor defines the event points of a graph search, returning an error from any of them halts the search with that error
+type Visitor interface {
+	//DiscoverNode is called when a node is first reached
+	DiscoverNode(Nodes) error
+	//FinishNode is called once all the sockets of a node have been examined
+	FinishNode(Nodes) error
+	//ExamineEdge is called for every socket leaving a discovered node
+	ExamineEdge(*Socket) error
+	//TreeEdge is called for a socket that leads to an undiscovered node
+	TreeEdge(*Socket) error
+	//BackEdge is called for a socket that leads to an ancestor of the node
+	BackEdge(*Socket) error
+	//ForwardEdge is called for a socket that leads to a finished descendant of the node
+	ForwardEdge(*Socket) error
+	//CrossEdge is called for a socket that leads to a finished node that is neither an ancestor nor a descendant
+	CrossEdge(*Socket) error
+}
+
+//BaseVisitor provides a Visitor whose methods do nothing, embed it to implement only the events of interest
+type BaseVisitor struct{}
+
+//DiscoverNode implements Visitor
+func (BaseVisitor) DiscoverNode(Nodes) error { return nil }
+
+//FinishNode implements Visitor
+func (BaseVisitor) FinishNode(Nodes) error { return nil }
+
+//ExamineEdge implements Visitor
+func (BaseVisitor) ExamineEdge(*Socket) error { return nil }
+
+//TreeEdge implements Visitor
+func (BaseVisitor) TreeEdge(*Socket) error { return nil }
+
+//BackEdge implements Visitor
+func (BaseVisitor) BackEdge(*Socket) error { return nil }
+
+//ForwardEdge implements Visitor
+func (BaseVisitor) ForwardEdge(*Socket) error { return nil }
+
+//CrossEdge implements Visitor
+func (BaseVisitor) CrossEdge(*Socket) error { return nil }
+
+//node colours used by the visit engines
+const (
+	visitUnseen = iota
+	visitActive
+	visitFinished
+)
+
+//visitState holds the colours and discovery order of the nodes of a search
+type visitState struct {
+	colors   map[Nodes]int
+	discover map[Nodes]int
+	time     int
+}
+
+func newVisitState() *visitState {
+	return &visitState{
+		colors:   make(map[Nodes]int),
+		discover: make(map[Nodes]int),
+	}
+}
+
+func (s *visitState) discovered(n Nodes, v Visitor) error {
+	s.colors[n] = visitActive
+	s.discover[n] = s.time
+	s.time++
+	return v.DiscoverNode(n)
+}
+
+//DepthFirstVisit runs a depth-first search from the node, classifying every socket it meets for the visitor
+func DepthFirstVisit(n Nodes, v Visitor) error {
+	return depthFirstVisit(newVisitState(), n, v)
+}
+
+//DepthFirstVisitAll runs a depth-first search over every node of the graph, restarting from the next undiscovered node until all are covered
+func DepthFirstVisitAll(g Graphs, v Visitor) error {
+	state := newVisitState()
+
+	for _, n := range g.nodeSet().AllNodes() {
+		if state.colors[n] != visitUnseen {
+			continue
+		}
+
+		if err := depthFirstVisit(state, n, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func depthFirstVisit(state *visitState, root Nodes, v Visitor) error {
+	if err := state.discovered(root, v); err != nil {
+		return err
+	}
+
+	stack := NewCache()
+	stack.AddCache(root)
+
+	for stack.Length() > 0 {
+		top, _ := stack.LastCache()
+		node := top.Node
+
+		if top.Itr.Next() != nil {
+			state.colors[node] = visitFinished
+			stack.Uncache()
+
+			if err := v.FinishNode(node); err != nil {
+				return err
+			}
+			continue
+		}
+
+		soc, ok := top.Itr.Value().(*Socket)
+
+		if !ok {
+			return ErrBadEdgeType
+		}
+
+		if err := v.ExamineEdge(soc); err != nil {
+			return err
+		}
+
+		to := soc.To
+
+		var err error
+
+		switch state.colors[to] {
+		case visitUnseen:
+			if err = v.TreeEdge(soc); err == nil {
+				err = state.discovered(to, v)
+				stack.AddCache(to)
+			}
+		case visitActive:
+			err = v.BackEdge(soc)
+		default:
+			if state.discover[node] < state.discover[to] {
+				err = v.ForwardEdge(soc)
+			} else {
+				err = v.CrossEdge(soc)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//BreadthFirstVisit runs a breadth-first search from the node for the visitor, sockets to already discovered nodes are reported as cross edges
+func BreadthFirstVisit(n Nodes, v Visitor) error {
+	state := newVisitState()
+
+	if err := state.discovered(n, v); err != nil {
+		return err
+	}
+
+	queue := NewCache()
+	queue.AddCache(n)
+
+	for queue.Length() > 0 {
+		front, _ := queue.FirstCache()
+		node := front.Node
+
+		for front.Itr.Next() == nil {
+			soc, ok := front.Itr.Value().(*Socket)
+
+			if !ok {
+				return ErrBadEdgeType
+			}
+
+			if err := v.ExamineEdge(soc); err != nil {
+				return err
+			}
+
+			to := soc.To
+
+			if state.colors[to] != visitUnseen {
+				if err := v.CrossEdge(soc); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := v.TreeEdge(soc); err != nil {
+				return err
+			}
+
+			if err := state.discovered(to, v); err != nil {
+				return err
+			}
+
+			queue.AddCache(to)
+		}
+
+		state.colors[node] = visitFinished
+		queue.UncacheRight()
+
+		if err := v.FinishNode(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//cycleVisitor halts a depth-first search at the first back edge
+type cycleVisitor struct {
+	BaseVisitor
+}
+
+//BackEdge implements Visitor
+func (cycleVisitor) BackEdge(*Socket) error {
+	return ErrCyclicGraph
+}
+
+//HasCycle returns true if the graph contains a directed cycle
+func HasCycle(g Graphs) bool {
+	return DepthFirstVisitAll(g, cycleVisitor{}) == ErrCyclicGraph
+}
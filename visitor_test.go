@@ -0,0 +1,105 @@
+package ds
+
+import "testing"
+
+type edgeRecorder struct {
+	BaseVisitor
+	discovered, finished []interface{}
+	kinds                map[string]int
+}
+
+func (r *edgeRecorder) DiscoverNode(n Nodes) error {
+	r.discovered = append(r.discovered, n.Value())
+	return nil
+}
+
+func (r *edgeRecorder) FinishNode(n Nodes) error {
+	r.finished = append(r.finished, n.Value())
+	return nil
+}
+
+func (r *edgeRecorder) TreeEdge(*Socket) error {
+	r.kinds["tree"]++
+	return nil
+}
+
+func (r *edgeRecorder) BackEdge(*Socket) error {
+	r.kinds["back"]++
+	return nil
+}
+
+func (r *edgeRecorder) ForwardEdge(*Socket) error {
+	r.kinds["forward"]++
+	return nil
+}
+
+func (r *edgeRecorder) CrossEdge(*Socket) error {
+	r.kinds["cross"]++
+	return nil
+}
+
+func TestDepthFirstVisit(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+	gs.Bind(1, 2, 0)
+	gs.Bind(2, 3, 0)
+	gs.Bind(3, 1, 0)
+	gs.Bind(1, 3, 0)
+	gs.Bind(1, 4, 0)
+	gs.Bind(4, 3, 0)
+
+	rec := &edgeRecorder{kinds: make(map[string]int)}
+
+	if err := DepthFirstVisit(gs.Get(1), rec); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]int{"tree": 3, "back": 1, "forward": 1, "cross": 1}
+
+	for kind, count := range expected {
+		if rec.kinds[kind] != count {
+			t.Fatalf("Expected %d %s edges got %d", count, kind, rec.kinds[kind])
+		}
+	}
+
+	if len(rec.finished) != 4 || rec.finished[0] != 3 || rec.finished[3] != 1 {
+		t.Fatalf("Unexpected finish order: %v", rec.finished)
+	}
+
+	if !HasCycle(gs) {
+		t.Fatal("Expected graph to contain a cycle")
+	}
+
+	dag := NewGraph()
+	dag.Add(1, 2, 3)
+	dag.Bind(1, 2, 0)
+	dag.Bind(2, 3, 0)
+	dag.Bind(1, 3, 0)
+
+	if HasCycle(dag) {
+		t.Fatal("Expected graph to be acyclic")
+	}
+}
+
+func TestBreadthFirstVisit(t *testing.T) {
+	gs := NewGraph()
+	gs.Add(1, 2, 3, 4)
+	gs.Bind(1, 2, 0)
+	gs.Bind(1, 3, 0)
+	gs.Bind(2, 4, 0)
+	gs.Bind(3, 4, 0)
+
+	rec := &edgeRecorder{kinds: make(map[string]int)}
+
+	if err := BreadthFirstVisit(gs.Get(1), rec); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.discovered) != 4 || rec.discovered[1] != 2 || rec.discovered[2] != 3 {
+		t.Fatalf("Unexpected discovery order: %v", rec.discovered)
+	}
+
+	if rec.kinds["tree"] != 3 || rec.kinds["cross"] != 1 {
+		t.Fatalf("Unexpected edge classification: %v", rec.kinds)
+	}
+}